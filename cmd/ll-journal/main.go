@@ -6,22 +6,31 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 
+	"github.com/telluriancorp/ll-journal/internal/auth"
+	"github.com/telluriancorp/ll-journal/internal/cache"
 	"github.com/telluriancorp/ll-journal/internal/config"
+	"github.com/telluriancorp/ll-journal/internal/encryption"
+	"github.com/telluriancorp/ll-journal/internal/events"
 	"github.com/telluriancorp/ll-journal/internal/git"
 	"github.com/telluriancorp/ll-journal/internal/handlers"
 	"github.com/telluriancorp/ll-journal/internal/journal"
 	"github.com/telluriancorp/ll-journal/internal/migrations"
 	"github.com/telluriancorp/ll-journal/internal/s3"
 	"github.com/telluriancorp/ll-journal/internal/store"
+	"github.com/telluriancorp/ll-journal/internal/tlsconfig"
+	"github.com/telluriancorp/ll-journal/internal/webhooks"
 )
 
 const version = "0.1.0"
@@ -78,11 +87,12 @@ func main() {
 	var s3Client *s3.Client
 	if cfg.S3Endpoint != "" && cfg.S3AccessKey != "" && cfg.S3SecretKey != "" {
 		s3Client, err = s3.New(s3.Config{
-			Endpoint:  cfg.S3Endpoint,
-			Bucket:     cfg.S3Bucket,
-			AccessKey: cfg.S3AccessKey,
-			SecretKey: cfg.S3SecretKey,
-			Region:    "us-east-1",
+			Endpoint:      cfg.S3Endpoint,
+			Bucket:        cfg.S3Bucket,
+			AccessKey:     cfg.S3AccessKey,
+			SecretKey:     cfg.S3SecretKey,
+			Region:        "us-east-1",
+			PresignExpiry: time.Duration(cfg.PresignExpirySeconds) * time.Second,
 		})
 		if err != nil {
 			log.Fatalf("Failed to initialize S3 client: %v", err)
@@ -102,8 +112,124 @@ func main() {
 	}
 	log.Printf("Git client initialized (root: %s)", cfg.GitRoot)
 
+	// Initialize encryption at rest
+	serviceOpts := []journal.Option{
+		journal.WithAttachmentMaxBytes(cfg.AttachmentMaxBytes),
+		journal.WithCollabCheckpointInterval(time.Duration(cfg.CollabCheckpointSeconds) * time.Second),
+		journal.WithCommitCoalescing(time.Duration(cfg.CommitCoalesceSeconds) * time.Second),
+	}
+	keyring, err := loadKeyring(cfg)
+	if err != nil {
+		log.Fatalf("Failed to load encryption keys: %v", err)
+	}
+	if keyring != nil {
+		serviceOpts = append(serviceOpts, journal.WithEncryption(encryption.New(st, keyring)))
+		log.Printf("Encryption at rest enabled (master key: %s)", keyring.ActiveKeyID())
+	} else {
+		if envMode == "production" {
+			log.Printf("Warning: encryption at rest is disabled in production")
+		}
+	}
+
+	// Initialize read-through cache
+	contentCache, err := newCache(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize cache: %v", err)
+	}
+	if contentCache != nil {
+		serviceOpts = append(serviceOpts, journal.WithCache(contentCache))
+	}
+	if tiered, ok := contentCache.(*cache.Tiered); ok {
+		go tiered.Listen(context.Background())
+	}
+
+	// Initialize webhook delivery
+	dispatcher, err := webhooks.NewDispatcher(st, time.Duration(cfg.WebhookTimeoutSeconds)*time.Second,
+		cfg.WebhookMaxAttempts, cfg.WebhookAllowedNetworks)
+	if err != nil {
+		log.Fatalf("Failed to initialize webhooks: %v", err)
+	}
+	serviceOpts = append(serviceOpts, journal.WithWebhooks(dispatcher), journal.WithAdmins(cfg.AdminSubs))
+
+	// Initialize domain event publishers. In-process consumers subscribe to
+	// the channel; external ones to NATS or Postgres NOTIFY.
+	eventChannel := events.NewChannel()
+	eventLog := events.NewLog(st, cfg.DatabaseURL)
+	serviceOpts = append(serviceOpts, journal.WithEvents(eventChannel), journal.WithEventLog(eventLog))
+	if cfg.NATSURL != "" {
+		natsPublisher, err := events.NewNATS(cfg.NATSURL, cfg.NATSSubject, cfg.NATSCAFile)
+		if err != nil {
+			log.Fatalf("Failed to initialize NATS publisher: %v", err)
+		}
+		serviceOpts = append(serviceOpts, journal.WithEvents(natsPublisher))
+		log.Printf("Publishing events to NATS (subject: %s.*)", cfg.NATSSubject)
+	}
+	if cfg.EventsNotifyChannel != "" {
+		serviceOpts = append(serviceOpts, journal.WithEvents(events.NewPostgres(st.DB(), cfg.EventsNotifyChannel)))
+		log.Printf("Publishing events to Postgres NOTIFY (channel: %s)", cfg.EventsNotifyChannel)
+	}
+
 	// Initialize journal service
-	journalService := journal.NewService(st, s3Client, gitClient)
+	journalService := journal.NewService(st, s3Client, gitClient, serviceOpts...)
+
+	// Key rotation runs as a one-off command: ll-journal rotate-keys
+	if len(os.Args) > 1 && os.Args[1] == "rotate-keys" {
+		if err := journalService.RotateEncryptionKeys(context.Background()); err != nil {
+			log.Fatalf("Key rotation failed: %v", err)
+		}
+		log.Printf("Key rotation completed")
+		return
+	}
+
+	// Deliver queued webhook events, retrying failures as they come due
+	go dispatcher.Run(context.Background(), 15*time.Second)
+
+	// Wake change streams when any instance logs an event
+	go func() {
+		if err := eventLog.Listen(context.Background()); err != nil {
+			log.Printf("Warning: change streams will not update: %v", err)
+		}
+	}()
+
+	// Remove expired direct uploads, events too old to resume a change
+	// stream from, and request nonces that could no longer be replayed
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			if n, err := journalService.CleanupExpiredUploads(context.Background()); err != nil {
+				log.Printf("Warning: failed to clean up expired uploads: %v", err)
+			} else if n > 0 {
+				log.Printf("Cleaned up %d expired uploads", n)
+			}
+			if n, err := journalService.CleanupEventLog(context.Background()); err != nil {
+				log.Printf("Warning: failed to clean up event log: %v", err)
+			} else if n > 0 {
+				log.Printf("Cleaned up %d logged events", n)
+			}
+			if st != nil {
+				if n, err := st.DeleteExpiredNonces(context.Background()); err != nil {
+					log.Printf("Warning: failed to clean up request nonces: %v", err)
+				} else if n > 0 {
+					log.Printf("Cleaned up %d request nonces", n)
+				}
+			}
+		}
+	}()
+
+	// Initialize request authentication
+	verifier, err := newVerifier(cfg, st)
+	if err != nil {
+		log.Fatalf("Failed to initialize authentication: %v", err)
+	}
+	if cfg.AuthMode == auth.ModeHeader {
+		if envMode == "production" {
+			log.Fatalf("Production mode requires signed proxy requests; set LL_JOURNAL_AUTH_MODE to hmac or jwt")
+		}
+		log.Printf("Warning: trusting unsigned X-User-Sub headers")
+	} else {
+		log.Printf("Request authentication enabled (mode: %s)", cfg.AuthMode)
+	}
 
 	// Initialize handlers
 	h := handlers.New(journalService)
@@ -117,39 +243,269 @@ func main() {
 	// Health check endpoint
 	r.Get("/health", healthHandler)
 
+	// Metrics reveal cache activity, so scrapers authenticate like API clients
+	r.With(auth.Middleware(verifier)).Get("/metrics", h.Metrics)
+
 	// API routes
 	r.Route("/api/journals", func(r chi.Router) {
+		r.Use(auth.Middleware(verifier))
+
 		r.Post("/", h.CreateJournal)
 		r.Get("/", h.ListJournals)
+		r.Get("/on-this-day", h.OnThisDay)
 		r.Get("/{id}", h.GetJournal)
 		r.Put("/{id}", h.UpdateJournal)
 		r.Delete("/{id}", h.DeleteJournal)
+		r.Get("/{id}/key-envelope", h.GetKeyEnvelope)
+		r.Put("/{id}/key-envelope", h.UpdateKeyEnvelope)
+
+		// Membership routes
+		r.Get("/{id}/members", h.ListMembers)
+		r.Delete("/{id}/members/{userSub}", h.RemoveMember)
+		r.Post("/{id}/invites", h.InviteMember)
+		r.Get("/{id}/invites", h.ListInvites)
+		r.Delete("/{id}/invites/{inviteId}", h.RevokeInvite)
+
+		// Template routes
+		r.Put("/{id}/default-template", h.SetDefaultTemplate)
+
+		// Link graph of the journal's [[date]] links
+		r.Get("/{id}/graph", h.GetLinkGraph)
+
+		// Writing statistics
+		r.Get("/{id}/stats", h.GetJournalStats)
+		r.Get("/{id}/calendar", h.GetCalendar)
+
+		// ICS calendar feeds
+		r.Post("/{id}/feeds", h.CreateCalendarFeed)
+		r.Get("/{id}/feeds", h.ListCalendarFeeds)
+		r.Delete("/{id}/feeds/{feedId}", h.RevokeCalendarFeed)
+
+		// Unpublished drafts
+		r.Get("/{id}/drafts", h.ListDrafts)
 
 		// Entry routes
 		r.Route("/{journalId}/entries", func(r chi.Router) {
 			r.Post("/", h.CreateEntry)
 			r.Get("/", h.ListEntries)
-			r.Get("/{date}", h.GetEntry)
-			r.Put("/{date}", h.UpdateEntry)
-			r.Delete("/{date}", h.DeleteEntry)
 
-			// Version routes
-			r.Get("/{date}/versions", h.ListVersions)
-			r.Get("/{date}/versions/{commit}", h.GetVersion)
+			// Routes for one day's entry; {date} may also be "today" or "yesterday"
+			r.Route("/{date}", func(r chi.Router) {
+				r.Use(h.ResolveEntryDate)
+
+				r.Get("/", h.GetEntry)
+				r.Get("/content", h.GetEntryContent)
+				r.Put("/", h.UpdateEntry)
+				r.Delete("/", h.DeleteEntry)
+
+				// Live collaborative editing over WebSocket
+				r.Get("/collab", h.CollabEntry)
+
+				// Draft routes; saving a draft does not commit
+				r.Get("/draft", h.GetDraft)
+				r.Put("/draft", h.SaveDraft)
+				r.Delete("/draft", h.DiscardDraft)
+				r.Post("/draft/publish", h.PublishDraft)
+
+				// Version routes
+				r.Get("/versions", h.ListVersions)
+				r.Get("/versions/{commit}", h.GetVersion)
+
+				// Entries linking to this day
+				r.Get("/backlinks", h.ListBacklinks)
+
+				// Attachment routes
+				r.Post("/attachments", h.UploadAttachment)
+				r.Get("/attachments", h.ListAttachments)
+				r.Get("/attachments/{attachmentId}", h.GetAttachment)
+				r.Get("/attachments/{attachmentId}/content", h.DownloadAttachment)
+				r.Delete("/attachments/{attachmentId}", h.DeleteAttachment)
+
+				// Direct S3 upload and download routes
+				r.Post("/uploads", h.RequestUpload)
+				r.Post("/uploads/{uploadId}/confirm", h.ConfirmUpload)
+				r.Get("/download", h.GetEntryDownloadURL)
+
+				// Share link routes
+				r.Post("/shares", h.CreateShareLink)
+				r.Get("/shares", h.ListShareLinks)
+				r.Delete("/shares/{shareId}", h.RevokeShareLink)
+				r.Get("/shares/{shareId}/access", h.ListShareAccess)
+			})
 		})
 	})
 
+	// Public share links are deliberately unauthenticated
+	r.Get("/share/{token}", h.GetSharedEntry)
+	r.Post("/share/{token}", h.GetSharedEntry)
+	r.Get("/share/{token}/attachments/{attachmentId}", h.GetSharedAttachment)
+
+	// Calendar feeds are authenticated by the token in the URL
+	r.Get("/feeds/{token}", h.GetCalendarFeed)
+
+	r.Route("/api/events", func(r chi.Router) {
+		r.Use(auth.Middleware(verifier))
+
+		r.Get("/", h.StreamEvents)
+	})
+
+	r.Route("/api/invites", func(r chi.Router) {
+		r.Use(auth.Middleware(verifier))
+
+		r.Get("/", h.ListMyInvites)
+		r.Post("/{inviteId}/accept", h.AcceptInvite)
+	})
+
+	r.Route("/api/settings", func(r chi.Router) {
+		r.Use(auth.Middleware(verifier))
+
+		r.Get("/", h.GetSettings)
+		r.Put("/", h.UpdateSettings)
+	})
+
+	r.Route("/api/stats", func(r chi.Router) {
+		r.Use(auth.Middleware(verifier))
+
+		r.Get("/", h.GetUserStats)
+	})
+
+	r.Route("/api/sync", func(r chi.Router) {
+		r.Use(auth.Middleware(verifier))
+
+		r.Get("/", h.GetSyncChanges)
+		r.Post("/", h.PushSyncChanges)
+	})
+
+	r.Route("/api/templates", func(r chi.Router) {
+		r.Use(auth.Middleware(verifier))
+
+		r.Post("/", h.CreateTemplate)
+		r.Get("/", h.ListTemplates)
+		r.Get("/{templateId}", h.GetTemplate)
+		r.Put("/{templateId}", h.UpdateTemplate)
+		r.Delete("/{templateId}", h.DeleteTemplate)
+	})
+
+	r.Route("/api/webhooks", func(r chi.Router) {
+		r.Use(auth.Middleware(verifier))
+
+		r.Post("/", h.CreateWebhook)
+		r.Get("/", h.ListWebhooks)
+		r.Get("/{webhookId}", h.GetWebhook)
+		r.Put("/{webhookId}", h.UpdateWebhook)
+		r.Delete("/{webhookId}", h.DeleteWebhook)
+		r.Get("/{webhookId}/deliveries", h.ListWebhookDeliveries)
+		r.Post("/{webhookId}/deliveries/{deliveryId}/replay", h.ReplayWebhookDelivery)
+	})
+
 	// Start server
 	addr := cfg.SocketAddr()
 	log.Printf("LL-Journal version: %s", version)
 	log.Printf("Starting LL-Journal on %s", addr)
-	log.Printf("Note: Authentication and routing handled by LL-proxy gateway")
 
-	if err := http.ListenAndServe(addr, r); err != nil {
+	server := &http.Server{Addr: addr, Handler: r}
+
+	if cfg.TLSCertFile == "" {
+		if envMode == "production" {
+			log.Printf("Warning: serving plain HTTP in production")
+		}
+		if err := server.ListenAndServe(); err != nil {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+		return
+	}
+
+	reloader, err := tlsconfig.New(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCAFile, cfg.TLSAllowedClients)
+	if err != nil {
+		log.Fatalf("Failed to load TLS certificates: %v", err)
+	}
+	go reloader.Watch(context.Background(), 30*time.Second)
+	server.TLSConfig = reloader.TLSConfig()
+
+	if reloader.MutualTLS() {
+		log.Printf("Serving HTTPS with client certificate verification")
+	} else {
+		log.Printf("Serving HTTPS")
+	}
+	if err := server.ListenAndServeTLS("", ""); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
 
+// loadKeyring returns the configured master keys, or nil when encryption is disabled
+func loadKeyring(cfg *config.Config) (*encryption.Keyring, error) {
+	if cfg.EncryptionKeyringFile != "" {
+		return encryption.LoadKeyring(cfg.EncryptionKeyringFile)
+	}
+	if cfg.EncryptionMasterKey != "" {
+		return encryption.NewMasterKey("config", cfg.EncryptionMasterKey)
+	}
+	return nil, nil
+}
+
+// newVerifier returns the verifier for the configured auth mode. Nonces are
+// shared through Postgres when there is a database.
+func newVerifier(cfg *config.Config, st *store.Store) (auth.Verifier, error) {
+	maxSkew := time.Duration(cfg.AuthMaxSkewSeconds) * time.Second
+	var nonces auth.NonceStore
+	if st != nil {
+		nonces = st
+	}
+
+	switch cfg.AuthMode {
+	case auth.ModeHeader:
+		return auth.HeaderVerifier{}, nil
+	case auth.ModeHMAC:
+		return auth.NewHMACVerifier(cfg.AuthSecret, maxSkew, nonces)
+	case auth.ModeJWT:
+		jwtConfig := auth.JWTConfig{
+			Secret:   cfg.AuthSecret,
+			Issuer:   cfg.AuthIssuer,
+			Audience: cfg.AuthAudience,
+			MaxSkew:  maxSkew,
+			Nonces:   nonces,
+		}
+		if cfg.AuthJWKSFile != "" {
+			keys, err := auth.LoadJWKS(cfg.AuthJWKSFile)
+			if err != nil {
+				return nil, err
+			}
+			jwtConfig.Keys = keys
+		}
+		return auth.NewJWTVerifier(jwtConfig)
+	default:
+		return nil, fmt.Errorf("unknown auth mode %q", cfg.AuthMode)
+	}
+}
+
+// newCache returns the configured cache, or nil when caching is disabled
+func newCache(cfg *config.Config) (cache.Cache, error) {
+	if cfg.CacheMaxBytes <= 0 {
+		log.Printf("Cache disabled")
+		return nil, nil
+	}
+
+	ttl := time.Duration(cfg.CacheTTLSeconds) * time.Second
+	local := cache.NewLRU(cfg.CacheMaxBytes, ttl)
+	if cfg.RedisURL == "" {
+		log.Printf("In-process cache enabled (%d bytes, ttl %s)", cfg.CacheMaxBytes, ttl)
+		return local, nil
+	}
+
+	shared, err := cache.NewRedis(cfg.RedisURL, "ll-journal:", ttl)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shared.Ping(ctx); err != nil {
+		// The shared layer degrades to misses, so an unreachable server is not fatal
+		log.Printf("Warning: Redis cache unreachable: %v", err)
+	}
+	log.Printf("In-process cache enabled (%d bytes, ttl %s) with shared Redis cache", cfg.CacheMaxBytes, ttl)
+	return cache.NewTiered(local, shared), nil
+}
+
 func healthHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]string{
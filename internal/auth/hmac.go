@@ -0,0 +1,141 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package auth
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderUserSub   = "X-User-Sub"
+	HeaderTimestamp = "X-LL-Timestamp"
+	HeaderNonce     = "X-LL-Nonce"
+	HeaderSignature = "X-LL-Signature"
+
+	// bodyMemoryLimit is how much of a request body is held in memory while
+	// it is hashed; larger bodies are spooled to a temporary file
+	bodyMemoryLimit = 1 << 20
+)
+
+// HMACVerifier checks requests signed by LL-proxy with a shared secret.
+// The signature is hex(HMAC-SHA256(secret, payload)) where payload is
+//
+//	METHOD "\n" REQUEST-URI "\n" TIMESTAMP "\n" NONCE "\n" USER-SUB "\n" BODY-SHA256
+//
+// TIMESTAMP is in Unix seconds and BODY-SHA256 is the hex SHA-256 of the
+// request body, that of an empty body when there is none.
+type HMACVerifier struct {
+	secret  []byte
+	maxSkew time.Duration
+	nonces  *replayGuard
+}
+
+// NewHMACVerifier returns a verifier for the secret. nonces may be nil, in
+// which case replays are only caught by the instance that saw the original.
+func NewHMACVerifier(secret string, maxSkew time.Duration, nonces NonceStore) (*HMACVerifier, error) {
+	if len(secret) < 32 {
+		return nil, fmt.Errorf("hmac secret must be at least 32 bytes")
+	}
+	if maxSkew <= 0 {
+		maxSkew = DefaultMaxSkew
+	}
+	return &HMACVerifier{secret: []byte(secret), maxSkew: maxSkew, nonces: newReplayGuard("hmac:", nonces)}, nil
+}
+
+func (v *HMACVerifier) Verify(r *http.Request) (string, error) {
+	userSub := r.Header.Get(HeaderUserSub)
+	timestamp := r.Header.Get(HeaderTimestamp)
+	nonce := r.Header.Get(HeaderNonce)
+	signature := r.Header.Get(HeaderSignature)
+	if userSub == "" || timestamp == "" || nonce == "" || signature == "" {
+		return "", ErrMissingCredentials
+	}
+
+	bodySum, err := hashBody(r)
+	if err != nil {
+		return "", err
+	}
+	given, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(given, Sign(v.secret, r.Method, r.URL.RequestURI(), timestamp, nonce, userSub, bodySum)) {
+		return "", ErrInvalidSignature
+	}
+
+	// Check freshness only after the signature, so the timestamp is trusted
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return "", ErrStale
+	}
+	signedAt := time.Unix(unix, 0)
+	if skew := time.Since(signedAt); skew > v.maxSkew || skew < -v.maxSkew {
+		return "", ErrStale
+	}
+	fresh, err := v.nonces.check(r.Context(), nonce, signedAt.Add(v.maxSkew))
+	if err != nil {
+		return "", err
+	}
+	if !fresh {
+		return "", ErrReplayed
+	}
+	return userSub, nil
+}
+
+// Sign computes the request signature LL-proxy sends in X-LL-Signature.
+// bodySHA256 is the hex SHA-256 of the request body.
+func Sign(secret []byte, method, requestURI, timestamp, nonce, userSub, bodySHA256 string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s\n%s\n%s", method, requestURI, timestamp, nonce, userSub, bodySHA256)
+	return mac.Sum(nil)
+}
+
+// hashBody reads the request body and returns its hex SHA-256, putting an
+// identical body back on the request for the handler. Bodies larger than
+// bodyMemoryLimit are spooled to an unlinked temporary file, which goes away
+// when the body is closed.
+func hashBody(r *http.Request) (string, error) {
+	sum := sha256.New()
+	if r.Body == nil || r.Body == http.NoBody {
+		return hex.EncodeToString(sum.Sum(nil)), nil
+	}
+
+	var buf bytes.Buffer
+	n, err := io.CopyN(io.MultiWriter(&buf, sum), r.Body, bodyMemoryLimit+1)
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read request body: %w", err)
+	}
+	if n <= bodyMemoryLimit {
+		r.Body = io.NopCloser(bytes.NewReader(buf.Bytes()))
+		return hex.EncodeToString(sum.Sum(nil)), nil
+	}
+
+	spool, err := os.CreateTemp("", "ll-journal-body-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to spool request body: %w", err)
+	}
+	os.Remove(spool.Name())
+	if _, err := spool.Write(buf.Bytes()); err != nil {
+		spool.Close()
+		return "", fmt.Errorf("failed to spool request body: %w", err)
+	}
+	if _, err := io.Copy(io.MultiWriter(spool, sum), r.Body); err != nil {
+		spool.Close()
+		return "", fmt.Errorf("failed to read request body: %w", err)
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		spool.Close()
+		return "", fmt.Errorf("failed to spool request body: %w", err)
+	}
+	r.Body = spool
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
@@ -0,0 +1,153 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// memoryNonces is a NonceStore shared by verifiers standing in for replicas
+type memoryNonces struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func (m *memoryNonces) RecordNonce(_ context.Context, nonce string, _ time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.seen[nonce] {
+		return false, nil
+	}
+	m.seen[nonce] = true
+	return true, nil
+}
+
+type failingNonces struct{}
+
+func (failingNonces) RecordNonce(context.Context, string, time.Time) (bool, error) {
+	return false, errors.New("database unavailable")
+}
+
+func signedRequest(t *testing.T, method, target, body, nonce string, signedAt time.Time) *http.Request {
+	t.Helper()
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	sum := sha256.Sum256([]byte(body))
+	signature := Sign([]byte(testSecret), method, r.URL.RequestURI(), timestamp, nonce, "user-1", hex.EncodeToString(sum[:]))
+	r.Header.Set(HeaderUserSub, "user-1")
+	r.Header.Set(HeaderTimestamp, timestamp)
+	r.Header.Set(HeaderNonce, nonce)
+	r.Header.Set(HeaderSignature, hex.EncodeToString(signature))
+	return r
+}
+
+func TestHMACVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(r *http.Request)
+		age    time.Duration
+		want   error
+	}{
+		{name: "valid"},
+		{name: "missing signature", tamper: func(r *http.Request) { r.Header.Del(HeaderSignature) }, want: ErrMissingCredentials},
+		{name: "other user", tamper: func(r *http.Request) { r.Header.Set(HeaderUserSub, "user-2") }, want: ErrInvalidSignature},
+		{name: "other path", tamper: func(r *http.Request) { r.URL.Path = "/api/journals/other" }, want: ErrInvalidSignature},
+		{name: "other method", tamper: func(r *http.Request) { r.Method = http.MethodDelete }, want: ErrInvalidSignature},
+		{name: "other body", tamper: func(r *http.Request) {
+			r.Body = io.NopCloser(strings.NewReader(`{"content":"forged"}`))
+		}, want: ErrInvalidSignature},
+		{name: "malformed signature", tamper: func(r *http.Request) { r.Header.Set(HeaderSignature, "zz") }, want: ErrInvalidSignature},
+		{name: "stale", age: 2 * time.Minute, want: ErrStale},
+		{name: "from the future", age: -2 * time.Minute, want: ErrStale},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := NewHMACVerifier(testSecret, time.Minute, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := signedRequest(t, http.MethodPut, "/api/journals/j1?x=1", `{"content":"hello"}`, "nonce-"+strconv.Itoa(i), time.Now().Add(-tt.age))
+			if tt.tamper != nil {
+				tt.tamper(r)
+			}
+			userSub, err := v.Verify(r)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.want)
+			}
+			if tt.want == nil && userSub != "user-1" {
+				t.Errorf("Verify() = %q, want user-1", userSub)
+			}
+		})
+	}
+}
+
+func TestHMACVerifyKeepsBody(t *testing.T) {
+	// Larger than bodyMemoryLimit, so it is spooled to a file
+	body := strings.Repeat("x", bodyMemoryLimit+10)
+	v, err := NewHMACVerifier(testSecret, time.Minute, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := signedRequest(t, http.MethodPost, "/api/journals", body, "n", time.Now())
+	if _, err := v.Verify(r); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	defer r.Body.Close()
+	got, err := io.ReadAll(r.Body)
+	if err != nil || string(got) != body {
+		t.Errorf("body after Verify() has %d bytes, %v; want %d", len(got), err, len(body))
+	}
+}
+
+func TestHMACReplay(t *testing.T) {
+	shared := &memoryNonces{seen: make(map[string]bool)}
+	first, _ := NewHMACVerifier(testSecret, time.Minute, shared)
+	second, _ := NewHMACVerifier(testSecret, time.Minute, shared)
+	local, _ := NewHMACVerifier(testSecret, time.Minute, nil)
+	unavailable, _ := NewHMACVerifier(testSecret, time.Minute, failingNonces{})
+
+	now := time.Now()
+	request := func(nonce string) *http.Request {
+		return signedRequest(t, http.MethodGet, "/api/journals", "", nonce, now)
+	}
+
+	if _, err := first.Verify(request("a")); err != nil {
+		t.Fatalf("first Verify() error = %v", err)
+	}
+	if _, err := first.Verify(request("a")); !errors.Is(err, ErrReplayed) {
+		t.Errorf("replay on the same instance: error = %v, want %v", err, ErrReplayed)
+	}
+	if _, err := second.Verify(request("a")); !errors.Is(err, ErrReplayed) {
+		t.Errorf("replay on another instance: error = %v, want %v", err, ErrReplayed)
+	}
+	if _, err := second.Verify(request("b")); err != nil {
+		t.Errorf("new nonce on another instance: error = %v", err)
+	}
+
+	if _, err := local.Verify(request("c")); err != nil {
+		t.Fatalf("Verify() without a shared store error = %v", err)
+	}
+	if _, err := local.Verify(request("c")); !errors.Is(err, ErrReplayed) {
+		t.Errorf("replay without a shared store: error = %v, want %v", err, ErrReplayed)
+	}
+
+	if _, err := unavailable.Verify(request("d")); err == nil {
+		t.Error("Verify() with an unreachable nonce store succeeded, want error")
+	}
+}
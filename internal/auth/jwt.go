@@ -0,0 +1,238 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// JWTConfig configures JWT verification. HS256 tokens are checked against
+// Secret; RS256/384/512 and ES256/384/512 tokens against Keys by kid.
+type JWTConfig struct {
+	Secret   string
+	Keys     map[string]crypto.PublicKey
+	Issuer   string
+	Audience string
+	MaxSkew  time.Duration
+
+	// Nonces, if set, shares accepted jti claims between instances
+	Nonces NonceStore
+}
+
+// JWTVerifier checks a bearer token forwarded by LL-proxy. Tokens must carry
+// sub, exp and jti, and each jti is accepted only once.
+type JWTVerifier struct {
+	cfg    JWTConfig
+	nonces *replayGuard
+}
+
+// asymmetricAlgs maps the supported JWKS-verified algorithms to their hash
+var asymmetricAlgs = map[string]crypto.Hash{
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+	"ES256": crypto.SHA256,
+	"ES384": crypto.SHA384,
+	"ES512": crypto.SHA512,
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Sub string          `json:"sub"`
+	Iss string          `json:"iss"`
+	Aud json.RawMessage `json:"aud"`
+	Exp *int64          `json:"exp"`
+	Nbf *int64          `json:"nbf"`
+	Iat *int64          `json:"iat"`
+	Jti string          `json:"jti"`
+}
+
+func NewJWTVerifier(cfg JWTConfig) (*JWTVerifier, error) {
+	if cfg.Secret == "" && len(cfg.Keys) == 0 {
+		return nil, fmt.Errorf("jwt verification needs a secret or a jwks file")
+	}
+	if cfg.Secret != "" && len(cfg.Secret) < 32 {
+		return nil, fmt.Errorf("jwt secret must be at least 32 bytes")
+	}
+	if cfg.MaxSkew <= 0 {
+		cfg.MaxSkew = DefaultMaxSkew
+	}
+	return &JWTVerifier{cfg: cfg, nonces: newReplayGuard("jwt:", cfg.Nonces)}, nil
+}
+
+func (v *JWTVerifier) Verify(r *http.Request) (string, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return "", ErrMissingCredentials
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("malformed token")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return "", fmt.Errorf("malformed token header: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("malformed token signature: %w", err)
+	}
+	if err := v.verifySignature(header, parts[0]+"."+parts[1], signature); err != nil {
+		return "", err
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return "", fmt.Errorf("malformed token claims: %w", err)
+	}
+	if err := v.checkClaims(r.Context(), claims); err != nil {
+		return "", err
+	}
+	return claims.Sub, nil
+}
+
+func (v *JWTVerifier) verifySignature(header jwtHeader, signed string, signature []byte) error {
+	if header.Alg == "HS256" {
+		if v.cfg.Secret == "" {
+			return fmt.Errorf("unsupported alg %q", header.Alg)
+		}
+		mac := hmac.New(sha256.New, []byte(v.cfg.Secret))
+		mac.Write([]byte(signed))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return ErrInvalidSignature
+		}
+		return nil
+	}
+
+	hashID, ok := asymmetricAlgs[header.Alg]
+	if !ok {
+		return fmt.Errorf("unsupported alg %q", header.Alg)
+	}
+	h := hashID.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+
+	key, ok := v.cfg.Keys[header.Kid]
+	if !ok {
+		return fmt.Errorf("unknown key id %q", header.Kid)
+	}
+
+	switch header.Alg[:2] {
+	case "RS":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key %q is not an RSA key", header.Kid)
+		}
+		if err := rsa.VerifyPKCS1v15(pub, hashID, digest, signature); err != nil {
+			return ErrInvalidSignature
+		}
+	case "ES":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key %q is not an EC key", header.Kid)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return ErrInvalidSignature
+		}
+		sr := new(big.Int).SetBytes(signature[:size])
+		ss := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(pub, digest, sr, ss) {
+			return ErrInvalidSignature
+		}
+	}
+	return nil
+}
+
+func (v *JWTVerifier) checkClaims(ctx context.Context, claims jwtClaims) error {
+	if claims.Sub == "" {
+		return fmt.Errorf("token has no subject")
+	}
+	if claims.Exp == nil {
+		return fmt.Errorf("token has no expiry")
+	}
+	// Without a jti a captured token could be replayed until it expires
+	if claims.Jti == "" {
+		return fmt.Errorf("token has no jti")
+	}
+
+	now := time.Now()
+	exp := time.Unix(*claims.Exp, 0)
+	if now.After(exp.Add(v.cfg.MaxSkew)) {
+		return ErrStale
+	}
+	if claims.Nbf != nil && now.Add(v.cfg.MaxSkew).Before(time.Unix(*claims.Nbf, 0)) {
+		return fmt.Errorf("token not yet valid")
+	}
+	if claims.Iat != nil && now.Add(v.cfg.MaxSkew).Before(time.Unix(*claims.Iat, 0)) {
+		return fmt.Errorf("token issued in the future")
+	}
+
+	if v.cfg.Issuer != "" && claims.Iss != v.cfg.Issuer {
+		return fmt.Errorf("unexpected issuer %q", claims.Iss)
+	}
+	if v.cfg.Audience != "" && !hasAudience(claims.Aud, v.cfg.Audience) {
+		return fmt.Errorf("token not intended for %q", v.cfg.Audience)
+	}
+
+	fresh, err := v.nonces.check(ctx, claims.Jti, exp.Add(v.cfg.MaxSkew))
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrReplayed
+	}
+	return nil
+}
+
+// hasAudience accepts aud as either a string or an array of strings
+func hasAudience(raw json.RawMessage, audience string) bool {
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return single == audience
+	}
+	var many []string
+	if err := json.Unmarshal(raw, &many); err != nil {
+		return false
+	}
+	for _, aud := range many {
+		if aud == audience {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.New("invalid json")
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func hs256Token(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := encode(map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + encode(claims)
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestJWTVerify(t *testing.T) {
+	exp := time.Now().Add(time.Minute).Unix()
+	tests := []struct {
+		name   string
+		claims map[string]interface{}
+		err    string
+	}{
+		{name: "valid", claims: map[string]interface{}{"sub": "user-1", "exp": exp, "jti": "a"}},
+		{name: "no jti", claims: map[string]interface{}{"sub": "user-1", "exp": exp}, err: "no jti"},
+		{name: "no subject", claims: map[string]interface{}{"exp": exp, "jti": "b"}, err: "no subject"},
+		{name: "no expiry", claims: map[string]interface{}{"sub": "user-1", "jti": "c"}, err: "no expiry"},
+		{name: "expired", claims: map[string]interface{}{"sub": "user-1", "exp": time.Now().Add(-2 * time.Minute).Unix(), "jti": "d"}, err: ErrStale.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := NewJWTVerifier(JWTConfig{Secret: testSecret, MaxSkew: time.Minute})
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := httptest.NewRequest("GET", "/api/journals", nil)
+			r.Header.Set("Authorization", "Bearer "+hs256Token(t, tt.claims))
+			userSub, err := v.Verify(r)
+			if tt.err == "" {
+				if err != nil || userSub != "user-1" {
+					t.Fatalf("Verify() = %q, %v; want user-1", userSub, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("Verify() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestJWTReplay(t *testing.T) {
+	shared := &memoryNonces{seen: make(map[string]bool)}
+	first, _ := NewJWTVerifier(JWTConfig{Secret: testSecret, Nonces: shared})
+	second, _ := NewJWTVerifier(JWTConfig{Secret: testSecret, Nonces: shared})
+
+	token := hs256Token(t, map[string]interface{}{"sub": "user-1", "exp": time.Now().Add(time.Minute).Unix(), "jti": "once"})
+	verify := func(v *JWTVerifier) error {
+		r := httptest.NewRequest("GET", "/api/journals", nil)
+		r.Header.Set("Authorization", "Bearer "+token)
+		_, err := v.Verify(r)
+		return err
+	}
+
+	if err := verify(first); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if err := verify(second); !errors.Is(err, ErrReplayed) {
+		t.Errorf("replay on another instance: error = %v, want %v", err, ErrReplayed)
+	}
+}
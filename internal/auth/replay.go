@@ -0,0 +1,73 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// NonceStore records nonces for every instance of the service, so a request
+// accepted by one cannot be replayed against another. RecordNonce reports
+// whether the nonce was new.
+type NonceStore interface {
+	RecordNonce(ctx context.Context, nonce string, expiresAt time.Time) (bool, error)
+}
+
+// replayGuard remembers nonces until they could no longer pass the
+// freshness check, so each one is accepted at most once. Nonces are checked
+// in memory first and then, if there is one, in the shared store.
+type replayGuard struct {
+	prefix string
+	shared NonceStore
+
+	mu        sync.Mutex
+	seen      map[string]time.Time
+	lastPrune time.Time
+}
+
+func newReplayGuard(prefix string, shared NonceStore) *replayGuard {
+	return &replayGuard{prefix: prefix, shared: shared, seen: make(map[string]time.Time)}
+}
+
+// check records nonce until expiresAt and reports whether it was new. A
+// shared store that cannot be reached rejects the request.
+func (g *replayGuard) check(ctx context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	if !g.checkLocal(nonce, expiresAt) {
+		return false, nil
+	}
+	if g.shared == nil {
+		return true, nil
+	}
+	recorded, err := g.shared.RecordNonce(ctx, g.prefix+nonce, expiresAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to record nonce: %w", err)
+	}
+	return recorded, nil
+}
+
+func (g *replayGuard) checkLocal(nonce string, expiresAt time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(g.lastPrune) > time.Minute {
+		for n, exp := range g.seen {
+			if now.After(exp) {
+				delete(g.seen, n)
+			}
+		}
+		g.lastPrune = now
+	}
+
+	if exp, ok := g.seen[nonce]; ok && now.Before(exp) {
+		return false
+	}
+	g.seen[nonce] = expiresAt
+	return true
+}
@@ -0,0 +1,131 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+// Package cache provides the read-through caches used by the journal
+// service: a bounded in-process LRU and an optional Redis-compatible shared
+// layer behind it.
+package cache
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Cache stores opaque values by key
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration)
+	Delete(ctx context.Context, keys ...string)
+	Stats() []Stats
+}
+
+// Stats reports the counters of one cache layer
+type Stats struct {
+	Layer     string
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Errors    uint64
+	Entries   int
+	Bytes     int64
+}
+
+// counters are shared by the cache layers
+type counters struct {
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+	errors    atomic.Uint64
+}
+
+func (c *counters) record(hit bool) {
+	if hit {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+}
+
+func (c *counters) stats(layer string) Stats {
+	return Stats{
+		Layer:     layer,
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Errors:    c.errors.Load(),
+	}
+}
+
+// invalidationChannel carries keys deleted on one instance to the local
+// caches of the others, one key per line
+const invalidationChannel = "invalidate"
+
+// LocalCache is a cache private to one instance
+type LocalCache interface {
+	Cache
+	Purge()
+}
+
+// SharedCache is a cache every instance reaches, which can also pass
+// messages between them
+type SharedCache interface {
+	Cache
+	GetWithTTL(ctx context.Context, key string) ([]byte, time.Duration, bool)
+	Publish(ctx context.Context, channel, message string)
+	Subscribe(ctx context.Context, channel string, onConnect func(), fn func(message string))
+}
+
+// Tiered checks a fast local cache before a shared one and fills the local
+// cache on shared hits. Deletes reach the shared cache and, through Listen,
+// the local caches of every other instance.
+type Tiered struct {
+	local  LocalCache
+	shared SharedCache
+}
+
+func NewTiered(local LocalCache, shared SharedCache) *Tiered {
+	return &Tiered{local: local, shared: shared}
+}
+
+func (t *Tiered) Get(ctx context.Context, key string) ([]byte, bool) {
+	if value, ok := t.local.Get(ctx, key); ok {
+		return value, true
+	}
+	value, ttl, ok := t.shared.GetWithTTL(ctx, key)
+	if ok {
+		// Expire with the shared entry, not after the local default
+		t.local.Set(ctx, key, value, ttl)
+	}
+	return value, ok
+}
+
+func (t *Tiered) Set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	t.local.Set(ctx, key, value, ttl)
+	t.shared.Set(ctx, key, value, ttl)
+}
+
+func (t *Tiered) Delete(ctx context.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	t.local.Delete(ctx, keys...)
+	t.shared.Delete(ctx, keys...)
+	t.shared.Publish(ctx, invalidationChannel, strings.Join(keys, "\n"))
+}
+
+func (t *Tiered) Stats() []Stats {
+	return append(t.local.Stats(), t.shared.Stats()...)
+}
+
+// Listen applies deletes made by other instances to the local cache until
+// ctx is done. Whenever the subscription (re)starts, the local cache is
+// purged, since deletes made while it was down were missed.
+func (t *Tiered) Listen(ctx context.Context) {
+	t.shared.Subscribe(ctx, invalidationChannel, t.local.Purge, func(message string) {
+		t.local.Delete(ctx, strings.Split(message, "\n")...)
+	})
+}
@@ -0,0 +1,125 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// LRU is an in-process cache bounded by total value size, with a TTL per entry
+type LRU struct {
+	maxBytes   int64
+	defaultTTL time.Duration
+
+	mu    sync.Mutex
+	bytes int64
+	order *list.List // front = most recently used
+	items map[string]*list.Element
+
+	counters
+}
+
+type lruItem struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLRU creates a cache holding at most maxBytes of values. Entries set
+// without a TTL expire after defaultTTL.
+func NewLRU(maxBytes int64, defaultTTL time.Duration) *LRU {
+	return &LRU{
+		maxBytes:   maxBytes,
+		defaultTTL: defaultTTL,
+		order:      list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+func (c *LRU) Get(_ context.Context, key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		c.record(false)
+		return nil, false
+	}
+	item := el.Value.(*lruItem)
+	if time.Now().After(item.expiresAt) {
+		c.remove(el)
+		c.record(false)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	c.record(true)
+	return item.value, true
+}
+
+func (c *LRU) Set(_ context.Context, key string, value []byte, ttl time.Duration) {
+	size := int64(len(value))
+	if size > c.maxBytes {
+		return
+	}
+	if ttl <= 0 {
+		ttl = c.defaultTTL
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+	c.items[key] = c.order.PushFront(&lruItem{key: key, value: value, expiresAt: time.Now().Add(ttl)})
+	c.bytes += size
+
+	for c.bytes > c.maxBytes {
+		c.remove(c.order.Back())
+		c.evictions.Add(1)
+	}
+}
+
+func (c *LRU) Delete(_ context.Context, keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := c.items[key]; ok {
+			c.remove(el)
+		}
+	}
+}
+
+// Purge drops every entry
+func (c *LRU) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.items = make(map[string]*list.Element)
+	c.bytes = 0
+}
+
+func (c *LRU) Stats() []Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats("memory")
+	stats.Entries = len(c.items)
+	stats.Bytes = c.bytes
+	return []Stats{stats}
+}
+
+// remove must be called with c.mu held
+func (c *LRU) remove(el *list.Element) {
+	item := c.order.Remove(el).(*lruItem)
+	delete(c.items, item.key)
+	c.bytes -= int64(len(item.value))
+}
@@ -0,0 +1,380 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	redisPoolSize    = 8
+	redisDialTimeout = 2 * time.Second
+	redisIOTimeout   = time.Second
+
+	// redisResubscribeDelay is how long a lost subscription waits to reconnect
+	redisResubscribeDelay = 2 * time.Second
+)
+
+// Redis is a shared cache speaking the RESP protocol, so it works against
+// Redis, Valkey, KeyDB or any local stand-in that implements GET/SET/DEL.
+// Failures are counted and treated as misses; the cache never fails a request.
+type Redis struct {
+	addr     string
+	password string
+	db       int
+	prefix   string
+	ttl      time.Duration
+
+	pool chan *redisConn
+
+	counters
+}
+
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// NewRedis parses a redis://[:password@]host:port[/db] URL. Keys are
+// namespaced with prefix; entries set without a TTL expire after defaultTTL.
+func NewRedis(rawURL, prefix string, defaultTTL time.Duration) (*Redis, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+	if u.Scheme != "redis" {
+		return nil, fmt.Errorf("invalid redis url scheme: %s", u.Scheme)
+	}
+
+	c := &Redis{
+		addr:   u.Host,
+		prefix: prefix,
+		ttl:    defaultTTL,
+		pool:   make(chan *redisConn, redisPoolSize),
+	}
+	if !strings.Contains(c.addr, ":") {
+		c.addr += ":6379"
+	}
+	if u.User != nil {
+		c.password, _ = u.User.Password()
+		if c.password == "" {
+			c.password = u.User.Username()
+		}
+	}
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		if c.db, err = strconv.Atoi(db); err != nil {
+			return nil, fmt.Errorf("invalid redis database: %s", db)
+		}
+	}
+	return c, nil
+}
+
+// Ping checks that the server is reachable
+func (c *Redis) Ping(ctx context.Context) error {
+	_, err := c.do(ctx, "PING")
+	return err
+}
+
+func (c *Redis) Get(ctx context.Context, key string) ([]byte, bool) {
+	reply, err := c.do(ctx, "GET", c.prefix+key)
+	if err != nil {
+		c.errors.Add(1)
+		c.record(false)
+		return nil, false
+	}
+	value, ok := reply.([]byte)
+	c.record(ok)
+	return value, ok
+}
+
+// GetWithTTL gets a value and how long it has left to live in the cache
+func (c *Redis) GetWithTTL(ctx context.Context, key string) ([]byte, time.Duration, bool) {
+	replies, err := c.pipeline(ctx, []string{"GET", c.prefix + key}, []string{"PTTL", c.prefix + key})
+	if err != nil {
+		c.errors.Add(1)
+		c.record(false)
+		return nil, 0, false
+	}
+	value, ok := replies[0].([]byte)
+	// Every entry is set with a TTL; none left means it expired in between
+	ms, _ := replies[1].(int64)
+	if ms <= 0 {
+		ok = false
+	}
+	c.record(ok)
+	if !ok {
+		return nil, 0, false
+	}
+	return value, time.Duration(ms) * time.Millisecond, true
+}
+
+func (c *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = c.ttl
+	}
+	if _, err := c.do(ctx, "SET", c.prefix+key, string(value), "PX", strconv.FormatInt(ttl.Milliseconds(), 10)); err != nil {
+		c.errors.Add(1)
+	}
+}
+
+func (c *Redis) Delete(ctx context.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	args := make([]string, 0, len(keys)+1)
+	args = append(args, "DEL")
+	for _, key := range keys {
+		args = append(args, c.prefix+key)
+	}
+	if _, err := c.do(ctx, args...); err != nil {
+		c.errors.Add(1)
+	}
+}
+
+func (c *Redis) Stats() []Stats {
+	return []Stats{c.stats("redis")}
+}
+
+// Publish sends a message to everyone subscribed to channel
+func (c *Redis) Publish(ctx context.Context, channel, message string) {
+	if _, err := c.do(ctx, "PUBLISH", c.prefix+channel, message); err != nil {
+		c.errors.Add(1)
+	}
+}
+
+// Subscribe calls fn with every message published to channel until ctx is
+// done, reconnecting when the connection drops. onConnect runs each time the
+// subscription starts, since messages sent while it was down are lost.
+func (c *Redis) Subscribe(ctx context.Context, channel string, onConnect func(), fn func(message string)) {
+	for {
+		if err := c.subscribe(ctx, channel, onConnect, fn); err != nil && ctx.Err() == nil {
+			c.errors.Add(1)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(redisResubscribeDelay):
+		}
+	}
+}
+
+func (c *Redis) subscribe(ctx context.Context, channel string, onConnect func(), fn func(message string)) error {
+	rc, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer rc.conn.Close()
+	stop := context.AfterFunc(ctx, func() { rc.conn.Close() })
+	defer stop()
+
+	if err := rc.expectOK("SUBSCRIBE", c.prefix+channel); err != nil {
+		return err
+	}
+	// Messages arrive whenever they are published
+	rc.conn.SetDeadline(time.Time{})
+	onConnect()
+
+	for {
+		reply, err := rc.readReply()
+		if err != nil {
+			return err
+		}
+		items, ok := reply.([]any)
+		if !ok || len(items) != 3 {
+			continue
+		}
+		kind, _ := items[0].([]byte)
+		payload, _ := items[2].([]byte)
+		if string(kind) == "message" {
+			fn(string(payload))
+		}
+	}
+}
+
+// do sends one command and reads its reply
+func (c *Redis) do(ctx context.Context, args ...string) (any, error) {
+	replies, err := c.pipeline(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return replies[0], nil
+}
+
+// pipeline sends commands in one write and reads their replies. Connections
+// are returned to the pool only after a clean round trip.
+func (c *Redis) pipeline(ctx context.Context, commands ...[]string) ([]any, error) {
+	rc, err := c.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(redisIOTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	rc.conn.SetDeadline(deadline)
+
+	replies, err := rc.pipeline(commands...)
+	if err != nil {
+		rc.conn.Close()
+		return nil, err
+	}
+	c.release(rc)
+	for _, reply := range replies {
+		if rerr, ok := reply.(redisError); ok {
+			return nil, rerr
+		}
+	}
+	return replies, nil
+}
+
+func (c *Redis) conn(ctx context.Context) (*redisConn, error) {
+	select {
+	case rc := <-c.pool:
+		return rc, nil
+	default:
+	}
+	return c.dial(ctx)
+}
+
+// dial opens and authenticates a new connection
+func (c *Redis) dial(ctx context.Context) (*redisConn, error) {
+	dialer := net.Dialer{Timeout: redisDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, err
+	}
+	rc := &redisConn{conn: conn, r: bufio.NewReader(conn)}
+	conn.SetDeadline(time.Now().Add(redisIOTimeout))
+
+	if c.password != "" {
+		if err := rc.expectOK("AUTH", c.password); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	if c.db != 0 {
+		if err := rc.expectOK("SELECT", strconv.Itoa(c.db)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return rc, nil
+}
+
+func (c *Redis) release(rc *redisConn) {
+	select {
+	case c.pool <- rc:
+	default:
+		rc.conn.Close()
+	}
+}
+
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+func (rc *redisConn) expectOK(args ...string) error {
+	reply, err := rc.roundTrip(args...)
+	if err != nil {
+		return err
+	}
+	if rerr, ok := reply.(redisError); ok {
+		return rerr
+	}
+	return nil
+}
+
+func (rc *redisConn) roundTrip(args ...string) (any, error) {
+	replies, err := rc.pipeline(args)
+	if err != nil {
+		return nil, err
+	}
+	return replies[0], nil
+}
+
+func (rc *redisConn) pipeline(commands ...[]string) ([]any, error) {
+	var b strings.Builder
+	for _, args := range commands {
+		fmt.Fprintf(&b, "*%d\r\n", len(args))
+		for _, arg := range args {
+			fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+		}
+	}
+	if _, err := io.WriteString(rc.conn, b.String()); err != nil {
+		return nil, err
+	}
+
+	replies := make([]any, len(commands))
+	for i := range replies {
+		reply, err := rc.readReply()
+		if err != nil {
+			return nil, err
+		}
+		replies[i] = reply
+	}
+	return replies, nil
+}
+
+// readReply decodes a single RESP reply. Bulk strings are returned as []byte,
+// nil bulk strings as nil, integers as int64 and simple strings as string.
+func (rc *redisConn) readReply() (any, error) {
+	line, err := rc.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return redisError(line[1:]), nil
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid bulk length: %w", err)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(rc.r, buf); err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid array length: %w", err)
+		}
+		items := make([]any, 0, max(n, 0))
+		for i := 0; i < n; i++ {
+			item, err := rc.readReply()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply %q", line)
+	}
+}
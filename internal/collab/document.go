@@ -0,0 +1,109 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package collab
+
+import (
+	"fmt"
+)
+
+// MaxHistory is how many past operations a document keeps for transforming
+// late operations. Clients further behind have to rejoin.
+const MaxHistory = 1000
+
+// Document is the server's authoritative copy of a shared text. Every
+// applied operation bumps its revision.
+type Document struct {
+	text     string
+	revision int
+	history  []*Operation // the operations that produced the last len(history) revisions
+}
+
+func NewDocument(text string) *Document {
+	return &Document{text: text}
+}
+
+func (d *Document) Text() string  { return d.text }
+func (d *Document) Revision() int { return d.revision }
+
+// Apply applies an operation a client made against the given revision. It is
+// transformed past every operation applied since, and the transformed
+// operation is returned for broadcasting.
+func (d *Document) Apply(revision int, op *Operation) (*Operation, error) {
+	behind := d.revision - revision
+	if revision < 0 || behind < 0 {
+		return nil, fmt.Errorf("invalid revision %d", revision)
+	}
+	if behind > len(d.history) {
+		return nil, fmt.Errorf("invalid revision %d: too far behind, rejoin to continue", revision)
+	}
+
+	for _, concurrent := range d.history[len(d.history)-behind:] {
+		var err error
+		if op, _, err = Transform(op, concurrent); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := d.commit(op); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// Peer is an editor that changes the text without following the document,
+// such as a save made outside the live session. It knows the document as of
+// some revision, with its own changes made on top.
+type Peer struct {
+	revision int
+	bridge   []*Operation // turns the peer's text into the document at revision
+}
+
+// NewPeer returns a peer whose text is the document's text at revision
+func (d *Document) NewPeer(revision int) *Peer {
+	return &Peer{revision: revision}
+}
+
+// ApplyPeer applies an operation the peer made to its own text. It is
+// transformed past what the peer has not seen, and the peer is moved to the
+// new revision so its next operation can be applied the same way.
+func (d *Document) ApplyPeer(p *Peer, op *Operation) (*Operation, error) {
+	behind := d.revision - p.revision
+	if p.revision < 0 || behind < 0 || behind > len(d.history) {
+		return nil, fmt.Errorf("invalid revision %d: too far behind", p.revision)
+	}
+
+	unseen := append(append([]*Operation(nil), p.bridge...), d.history[len(d.history)-behind:]...)
+	bridge := make([]*Operation, 0, len(unseen))
+	for _, concurrent := range unseen {
+		var transformed *Operation
+		var err error
+		if op, transformed, err = Transform(op, concurrent); err != nil {
+			return nil, err
+		}
+		bridge = append(bridge, transformed)
+	}
+
+	if err := d.commit(op); err != nil {
+		return nil, err
+	}
+	p.revision, p.bridge = d.revision, bridge
+	return op, nil
+}
+
+// commit applies an operation made against the current revision
+func (d *Document) commit(op *Operation) error {
+	text, err := op.Apply(d.text)
+	if err != nil {
+		return err
+	}
+	d.text = text
+	d.revision++
+	d.history = append(d.history, op)
+	if len(d.history) > MaxHistory {
+		d.history = append([]*Operation(nil), d.history[len(d.history)-MaxHistory:]...)
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+// Package collab implements operational transformation for live editing of
+// an entry's text. Operations follow the ot.js model: a sequence of retain,
+// insert and delete components that together span the whole document.
+// Lengths count Unicode code points.
+package collab
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+// MaxLength is the longest document, in code points, an operation may span.
+// Operations from clients are checked against it so that lengths cannot
+// overflow.
+const MaxLength = 1 << 24
+
+// component is one step of an operation: n > 0 retains n code points, n < 0
+// deletes -n, and a non-empty s inserts s
+type component struct {
+	n int
+	s string
+}
+
+func (c component) isRetain() bool { return c.n > 0 }
+func (c component) isDelete() bool { return c.n < 0 }
+func (c component) isInsert() bool { return c.s != "" }
+
+// Operation transforms a document of BaseLen code points into one of
+// TargetLen code points
+type Operation struct {
+	ops       []component
+	baseLen   int
+	targetLen int
+}
+
+func (o *Operation) BaseLen() int   { return o.baseLen }
+func (o *Operation) TargetLen() int { return o.targetLen }
+
+// IsNoop reports whether the operation leaves the document unchanged
+func (o *Operation) IsNoop() bool {
+	return len(o.ops) == 0 || (len(o.ops) == 1 && o.ops[0].isRetain())
+}
+
+// Retain skips over n code points
+func (o *Operation) Retain(n int) *Operation {
+	if n <= 0 {
+		return o
+	}
+	o.baseLen += n
+	o.targetLen += n
+	if last := len(o.ops) - 1; last >= 0 && o.ops[last].isRetain() {
+		o.ops[last].n += n
+	} else {
+		o.ops = append(o.ops, component{n: n})
+	}
+	return o
+}
+
+// Insert inserts s at the current position
+func (o *Operation) Insert(s string) *Operation {
+	if s == "" {
+		return o
+	}
+	o.targetLen += utf8.RuneCountInString(s)
+	last := len(o.ops) - 1
+	switch {
+	case last >= 0 && o.ops[last].isInsert():
+		o.ops[last].s += s
+	case last >= 0 && o.ops[last].isDelete():
+		// Keep inserts ahead of deletes so equal operations look the same
+		if last > 0 && o.ops[last-1].isInsert() {
+			o.ops[last-1].s += s
+		} else {
+			o.ops = append(o.ops, o.ops[last])
+			o.ops[last] = component{s: s}
+		}
+	default:
+		o.ops = append(o.ops, component{s: s})
+	}
+	return o
+}
+
+// Delete removes n code points
+func (o *Operation) Delete(n int) *Operation {
+	if n <= 0 {
+		return o
+	}
+	o.baseLen += n
+	if last := len(o.ops) - 1; last >= 0 && o.ops[last].isDelete() {
+		o.ops[last].n -= n
+	} else {
+		o.ops = append(o.ops, component{n: -n})
+	}
+	return o
+}
+
+// Apply applies the operation to text
+func (o *Operation) Apply(text string) (string, error) {
+	runes := []rune(text)
+	if len(runes) != o.baseLen {
+		return "", fmt.Errorf("invalid operation: expects a document of length %d, got %d", o.baseLen, len(runes))
+	}
+
+	var out bytes.Buffer
+	pos := 0
+	for _, c := range o.ops {
+		switch {
+		case c.isRetain():
+			if c.n > len(runes)-pos {
+				return "", fmt.Errorf("invalid operation: retain past the end of the document")
+			}
+			out.WriteString(string(runes[pos : pos+c.n]))
+			pos += c.n
+		case c.isInsert():
+			out.WriteString(c.s)
+		default:
+			if -c.n > len(runes)-pos {
+				return "", fmt.Errorf("invalid operation: delete past the end of the document")
+			}
+			pos -= c.n
+		}
+	}
+	if pos != len(runes) {
+		return "", fmt.Errorf("invalid operation: does not span the document")
+	}
+	return out.String(), nil
+}
+
+// TransformIndex moves a cursor position in the document before o to the
+// matching position after it
+func (o *Operation) TransformIndex(index int) int {
+	newIndex := index
+	pos := 0
+	for _, c := range o.ops {
+		if pos > index {
+			break
+		}
+		switch {
+		case c.isRetain():
+			pos += c.n
+		case c.isInsert():
+			newIndex += utf8.RuneCountInString(c.s)
+		default:
+			newIndex -= min(index-pos, -c.n)
+			pos -= c.n
+		}
+	}
+	return newIndex
+}
+
+// Transform takes concurrent operations a and b on the same document and
+// returns a' and b' such that applying a then b' equals applying b then a'.
+// When both insert at the same position, a's text comes first.
+func Transform(a, b *Operation) (*Operation, *Operation, error) {
+	if a.baseLen != b.baseLen {
+		return nil, nil, fmt.Errorf("invalid operation: concurrent operations have different base lengths")
+	}
+
+	aPrime, bPrime := &Operation{}, &Operation{}
+	ia, ib := 0, 0
+	next := func(ops []component, i *int) (component, bool) {
+		if *i >= len(ops) {
+			return component{}, false
+		}
+		c := ops[*i]
+		*i++
+		return c, true
+	}
+	ca, okA := next(a.ops, &ia)
+	cb, okB := next(b.ops, &ib)
+
+	for okA || okB {
+		if okA && ca.isInsert() {
+			aPrime.Insert(ca.s)
+			bPrime.Retain(utf8.RuneCountInString(ca.s))
+			ca, okA = next(a.ops, &ia)
+			continue
+		}
+		if okB && cb.isInsert() {
+			aPrime.Retain(utf8.RuneCountInString(cb.s))
+			bPrime.Insert(cb.s)
+			cb, okB = next(b.ops, &ib)
+			continue
+		}
+		if !okA || !okB {
+			return nil, nil, fmt.Errorf("invalid operation: operation is too short")
+		}
+
+		switch {
+		case ca.isRetain() && cb.isRetain():
+			n := min(ca.n, cb.n)
+			aPrime.Retain(n)
+			bPrime.Retain(n)
+			ca.n -= n
+			cb.n -= n
+		case ca.isDelete() && cb.isDelete():
+			// Both deleted the same text
+			n := min(-ca.n, -cb.n)
+			ca.n += n
+			cb.n += n
+		case ca.isDelete() && cb.isRetain():
+			n := min(-ca.n, cb.n)
+			aPrime.Delete(n)
+			ca.n += n
+			cb.n -= n
+		default: // retain and delete
+			n := min(ca.n, -cb.n)
+			bPrime.Delete(n)
+			ca.n -= n
+			cb.n += n
+		}
+		if ca.n == 0 {
+			ca, okA = next(a.ops, &ia)
+		}
+		if cb.n == 0 {
+			cb, okB = next(b.ops, &ib)
+		}
+	}
+	return aPrime, bPrime, nil
+}
+
+// Diff returns an operation that turns a into b. It keeps their common
+// prefix and suffix and replaces what lies between.
+func Diff(a, b string) *Operation {
+	ra, rb := []rune(a), []rune(b)
+	prefix := 0
+	for prefix < len(ra) && prefix < len(rb) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(ra)-prefix && suffix < len(rb)-prefix && ra[len(ra)-1-suffix] == rb[len(rb)-1-suffix] {
+		suffix++
+	}
+
+	op := &Operation{}
+	return op.Retain(prefix).
+		Delete(len(ra) - prefix - suffix).
+		Insert(string(rb[prefix : len(rb)-suffix])).
+		Retain(suffix)
+}
+
+// MarshalJSON encodes the operation as ot.js does: positive numbers retain,
+// negative numbers delete and strings insert
+func (o *Operation) MarshalJSON() ([]byte, error) {
+	parts := make([]interface{}, len(o.ops))
+	for i, c := range o.ops {
+		if c.isInsert() {
+			parts[i] = c.s
+		} else {
+			parts[i] = c.n
+		}
+	}
+	return json.Marshal(parts)
+}
+
+func (o *Operation) UnmarshalJSON(data []byte) error {
+	var parts []json.RawMessage
+	if err := json.Unmarshal(data, &parts); err != nil {
+		return fmt.Errorf("invalid operation: %w", err)
+	}
+
+	*o = Operation{}
+	for _, part := range parts {
+		if len(part) > 0 && part[0] == '"' {
+			var s string
+			if err := json.Unmarshal(part, &s); err != nil || s == "" {
+				return fmt.Errorf("invalid operation: bad insert %s", part)
+			}
+			if utf8.RuneCountInString(s) > MaxLength {
+				return fmt.Errorf("invalid operation: insert is too long")
+			}
+			o.Insert(s)
+		} else {
+			var n int
+			if err := json.Unmarshal(part, &n); err != nil || n == 0 {
+				return fmt.Errorf("invalid operation: bad component %s", part)
+			}
+			if n > MaxLength || n < -MaxLength {
+				return fmt.Errorf("invalid operation: component %d is too long", n)
+			}
+			if n > 0 {
+				o.Retain(n)
+			} else {
+				o.Delete(-n)
+			}
+		}
+		// Each component is at most MaxLength, so checking after every one
+		// keeps the running lengths far from overflowing
+		if o.baseLen > MaxLength || o.targetLen > MaxLength {
+			return fmt.Errorf("invalid operation: spans more than %d characters", MaxLength)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package collab
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func parseOp(t *testing.T, s string) *Operation {
+	t.Helper()
+	op := &Operation{}
+	if err := json.Unmarshal([]byte(s), op); err != nil {
+		t.Fatalf("unmarshal %s: %v", s, err)
+	}
+	return op
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		op   string
+		want string
+		err  string
+	}{
+		{name: "insert", text: "ab", op: `[1,"x",1]`, want: "axb"},
+		{name: "delete", text: "abc", op: `[1,-1,1]`, want: "ac"},
+		{name: "replace", text: "abc", op: `["z",-3]`, want: "z"},
+		{name: "code points", text: "héllo", op: `[2,-1,"L",2]`, want: "héLlo"},
+		{name: "empty document", text: "", op: `["hi"]`, want: "hi"},
+		{name: "too short", text: "abc", op: `[2]`, err: "expects a document of length"},
+		{name: "too long", text: "ab", op: `[3]`, err: "expects a document of length"},
+		{name: "delete too long", text: "ab", op: `[1,-2]`, err: "expects a document of length"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseOp(t, tt.op).Apply(tt.text)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Apply() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Apply() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Operations built without UnmarshalJSON can still be inconsistent; Apply
+// must report them rather than panic
+func TestApplyMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		op   *Operation
+	}{
+		{name: "retain past end", op: &Operation{ops: []component{{n: 5}}, baseLen: 2}},
+		{name: "delete past end", op: &Operation{ops: []component{{n: -5}}, baseLen: 2}},
+		{name: "short of end", op: &Operation{ops: []component{{n: 1}}, baseLen: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.op.Apply("ab"); err == nil {
+				t.Fatal("Apply() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestUnmarshalMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		op   string
+	}{
+		{name: "not an array", op: `{"retain":1}`},
+		{name: "zero", op: `[0]`},
+		{name: "empty insert", op: `[""]`},
+		{name: "fraction", op: `[1.5]`},
+		{name: "bool", op: `[true]`},
+		{name: "huge retain", op: `[9223372036854775807,"x",9223372036854775807,4]`},
+		{name: "huge delete", op: `[-9223372036854775808]`},
+		{name: "overflowing number", op: `[99999999999999999999]`},
+		{name: "sum over limit", op: `[16777216,16777216]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := &Operation{}
+			err := json.Unmarshal([]byte(tt.op), op)
+			if err == nil || !strings.Contains(err.Error(), "invalid operation") {
+				t.Fatalf("Unmarshal(%s) error = %v, want invalid operation", tt.op, err)
+			}
+		})
+	}
+}
+
+func TestTransform(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		a, b string
+		want string
+		err  bool
+	}{
+		{name: "inserts at different positions", text: "abc", a: `["x",3]`, b: `[3,"y"]`, want: "xabcy"},
+		{name: "inserts at same position, a first", text: "ab", a: `[1,"x",1]`, b: `[1,"y",1]`, want: "axyb"},
+		{name: "same delete", text: "abc", a: `[1,-1,1]`, b: `[1,-1,1]`, want: "ac"},
+		{name: "overlapping deletes", text: "abcd", a: `[-3,1]`, b: `[1,-3]`, want: ""},
+		{name: "insert inside delete", text: "abcd", a: `[2,"x",2]`, b: `[1,-2,1]`, want: "axd"},
+		{name: "delete and retain all", text: "ab", a: `[-2]`, b: `[2]`, want: ""},
+		{name: "different base lengths", text: "ab", a: `[2]`, b: `[3]`, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := parseOp(t, tt.a), parseOp(t, tt.b)
+			aPrime, bPrime, err := Transform(a, b)
+			if tt.err {
+				if err == nil {
+					t.Fatal("Transform() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Transform() error = %v", err)
+			}
+
+			afterA, err := a.Apply(tt.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+			left, err := bPrime.Apply(afterA)
+			if err != nil {
+				t.Fatalf("b' does not apply after a: %v", err)
+			}
+			afterB, err := b.Apply(tt.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+			right, err := aPrime.Apply(afterB)
+			if err != nil {
+				t.Fatalf("a' does not apply after b: %v", err)
+			}
+			if left != right || left != tt.want {
+				t.Errorf("a then b' = %q, b then a' = %q, want %q", left, right, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{name: "equal", a: "abc", b: "abc", want: `[3]`},
+		{name: "insert", a: "ac", b: "abc", want: `[1,"b",1]`},
+		{name: "delete", a: "abc", b: "ac", want: `[1,-1,1]`},
+		{name: "replace", a: "abc", b: "axc", want: `[1,"x",-1,1]`},
+		{name: "repeated", a: "aa", b: "aaa", want: `[2,"a"]`},
+		{name: "from empty", a: "", b: "hi", want: `["hi"]`},
+		{name: "to empty", a: "hi", b: "", want: `[-2]`},
+		{name: "code points", a: "héllo", b: "hèllo", want: `[1,"è",-1,3]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := Diff(tt.a, tt.b)
+			got, err := json.Marshal(op)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Diff = %s, want %s", got, tt.want)
+			}
+			text, err := op.Apply(tt.a)
+			if err != nil || text != tt.b {
+				t.Errorf("Apply(Diff) = %q, %v; want %q", text, err, tt.b)
+			}
+		})
+	}
+}
+
+func TestDocumentRejectsOversizedOp(t *testing.T) {
+	doc := NewDocument("ab")
+	op := &Operation{}
+	if err := json.Unmarshal([]byte(`[2,"x"]`), op); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := doc.Apply(0, op); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	// A retain far past the document, as a client could send it
+	bad := &Operation{ops: []component{{n: 1 << 40}}, baseLen: 3}
+	if _, err := doc.Apply(1, bad); err == nil {
+		t.Fatal("Apply() succeeded, want error")
+	}
+	if doc.Text() != "abx" || doc.Revision() != 1 {
+		t.Errorf("document changed to %q at revision %d", doc.Text(), doc.Revision())
+	}
+}
+
+func TestDocumentPeer(t *testing.T) {
+	doc := NewDocument("hello world")
+	peer := doc.NewPeer(doc.Revision())
+
+	// A client edits while the peer, unaware, saves twice on its own text
+	steps := []struct {
+		client string // op at the document's revision, or empty
+		peer   [2]string
+		want   string
+	}{
+		{client: `[11,"!"]`, peer: [2]string{"hello world", "Hello world"}, want: "Hello world!"},
+		{client: `["> ",12]`, peer: [2]string{"Hello world", "Hello, world"}, want: "> Hello, world!"},
+		{peer: [2]string{"Hello, world", "Hello, world."}, want: "> Hello, world.!"},
+	}
+	for i, step := range steps {
+		if step.client != "" {
+			if _, err := doc.Apply(doc.Revision(), parseOp(t, step.client)); err != nil {
+				t.Fatalf("step %d: Apply() error = %v", i, err)
+			}
+		}
+		if _, err := doc.ApplyPeer(peer, Diff(step.peer[0], step.peer[1])); err != nil {
+			t.Fatalf("step %d: ApplyPeer() error = %v", i, err)
+		}
+		if doc.Text() != step.want {
+			t.Errorf("step %d: text = %q, want %q", i, doc.Text(), step.want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package collab
+
+// Message types. Clients send op and cursor; the server sends the rest.
+const (
+	MessageInit   = "init"   // the document on joining: Text, Revision, Participants
+	MessageOp     = "op"     // an operation: from a client against Revision, from the server producing Revision
+	MessageAck    = "ack"    // the sender's operation was applied as Revision
+	MessageCursor = "cursor" // a participant's cursor or selection moved
+	MessageJoin   = "join"   // a participant joined
+	MessageLeave  = "leave"  // a participant left
+	MessageSaved  = "saved"  // Revision was checkpointed as commit CommitHash
+	MessageError  = "error"  // a client message was rejected
+)
+
+// Message is one WebSocket frame of the editing protocol
+type Message struct {
+	Type         string        `json:"type"`
+	Revision     int           `json:"revision"`
+	Op           *Operation    `json:"op,omitempty"`
+	Cursor       *Cursor       `json:"cursor,omitempty"`
+	Text         *string       `json:"text,omitempty"`
+	ClientID     string        `json:"client_id,omitempty"`
+	UserSub      string        `json:"user_sub,omitempty"`
+	CanEdit      *bool         `json:"can_edit,omitempty"`
+	Participants []Participant `json:"participants,omitempty"`
+	CommitHash   string        `json:"commit_hash,omitempty"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// Cursor is a caret at Position, or a selection from Position to
+// SelectionEnd. Positions count code points.
+type Cursor struct {
+	Position     int `json:"position"`
+	SelectionEnd int `json:"selection_end"`
+}
+
+// Transform moves the cursor past an operation applied to the document
+func (c Cursor) Transform(op *Operation) Cursor {
+	return Cursor{
+		Position:     op.TransformIndex(c.Position),
+		SelectionEnd: op.TransformIndex(c.SelectionEnd),
+	}
+}
+
+// Participant is one connection to a shared document
+type Participant struct {
+	ClientID string  `json:"client_id"`
+	UserSub  string  `json:"user_sub"`
+	CanEdit  bool    `json:"can_edit"`
+	Cursor   *Cursor `json:"cursor,omitempty"`
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -20,11 +21,59 @@ type Config struct {
 	Port        uint16 `json:"port"`
 	DatabaseURL string `json:"database_url"`
 	S3Endpoint  string `json:"s3_endpoint"`
-	S3Bucket   string `json:"s3_bucket"`
+	S3Bucket    string `json:"s3_bucket"`
 	S3AccessKey string `json:"s3_access_key"`
 	S3SecretKey string `json:"s3_secret_key"`
 	GitRoot     string `json:"git_root"`
 	LogLevel    string `json:"log_level"`
+
+	// Encryption at rest: either a single base64 master key or a keyring file
+	EncryptionMasterKey   string `json:"encryption_master_key"`
+	EncryptionKeyringFile string `json:"encryption_keyring_file"`
+
+	AttachmentMaxBytes   int64 `json:"attachment_max_bytes"`
+	PresignExpirySeconds int   `json:"presign_expiry_seconds"`
+
+	// Read-through cache: in-process LRU, optionally backed by Redis
+	CacheMaxBytes   int64  `json:"cache_max_bytes"`
+	CacheTTLSeconds int    `json:"cache_ttl_seconds"`
+	RedisURL        string `json:"redis_url"`
+
+	// Request authentication: "header" trusts X-User-Sub, "hmac" and "jwt"
+	// verify what LL-proxy forwards
+	AuthMode           string `json:"auth_mode"`
+	AuthSecret         string `json:"auth_secret"`
+	AuthJWKSFile       string `json:"auth_jwks_file"`
+	AuthIssuer         string `json:"auth_issuer"`
+	AuthAudience       string `json:"auth_audience"`
+	AuthMaxSkewSeconds int    `json:"auth_max_skew_seconds"`
+
+	// Native TLS; setting a client CA bundle requires client certificates
+	TLSCertFile       string   `json:"tls_cert_file"`
+	TLSKeyFile        string   `json:"tls_key_file"`
+	TLSClientCAFile   string   `json:"tls_client_ca_file"`
+	TLSAllowedClients []string `json:"tls_allowed_clients"`
+
+	// Webhooks: admins may create system-wide subscriptions that receive
+	// events from every journal
+	AdminSubs             []string `json:"admin_subs"`
+	WebhookMaxAttempts    int      `json:"webhook_max_attempts"`
+	WebhookTimeoutSeconds int      `json:"webhook_timeout_seconds"`
+
+	// Private networks webhooks may deliver to, as CIDRs or addresses; public addresses are always allowed
+	WebhookAllowedNetworks []string `json:"webhook_allowed_networks"`
+
+	// Domain events: published to NATS and/or Postgres NOTIFY when set
+	NATSURL             string `json:"nats_url"`
+	NATSSubject         string `json:"nats_subject"`
+	NATSCAFile          string `json:"nats_ca_file"`
+	EventsNotifyChannel string `json:"events_notify_channel"`
+
+	// Live editing: how often shared documents are saved as entry versions
+	CollabCheckpointSeconds int `json:"collab_checkpoint_seconds"`
+
+	// How long an author's successive edits of an entry amend one commit; 0 disables
+	CommitCoalesceSeconds int `json:"commit_coalesce_seconds"`
 }
 
 // Default returns default configuration
@@ -39,6 +88,22 @@ func Default() *Config {
 		S3SecretKey: "",
 		GitRoot:     "/var/lib/ll-journal/git",
 		LogLevel:    "info",
+
+		AttachmentMaxBytes:   25 << 20,
+		PresignExpirySeconds: 900,
+
+		CacheMaxBytes:   64 << 20,
+		CacheTTLSeconds: 300,
+
+		AuthMode:           "header",
+		AuthMaxSkewSeconds: 60,
+
+		WebhookMaxAttempts:    8,
+		WebhookTimeoutSeconds: 10,
+
+		NATSSubject: "ll-journal.events",
+
+		CollabCheckpointSeconds: 30,
 	}
 }
 
@@ -116,6 +181,131 @@ func (c *Config) loadFromEnv() {
 	if level := os.Getenv("LL_JOURNAL_LOG_LEVEL"); level != "" {
 		c.LogLevel = level
 	}
+
+	if key := os.Getenv("LL_JOURNAL_ENCRYPTION_MASTER_KEY"); key != "" {
+		c.EncryptionMasterKey = key
+	}
+
+	if path := os.Getenv("LL_JOURNAL_ENCRYPTION_KEYRING_FILE"); path != "" {
+		c.EncryptionKeyringFile = path
+	}
+
+	if maxStr := os.Getenv("LL_JOURNAL_ATTACHMENT_MAX_BYTES"); maxStr != "" {
+		if maxBytes, err := strconv.ParseInt(maxStr, 10, 64); err == nil {
+			c.AttachmentMaxBytes = maxBytes
+		}
+	}
+
+	if expiryStr := os.Getenv("LL_JOURNAL_PRESIGN_EXPIRY_SECONDS"); expiryStr != "" {
+		if expiry, err := strconv.Atoi(expiryStr); err == nil {
+			c.PresignExpirySeconds = expiry
+		}
+	}
+
+	if maxStr := os.Getenv("LL_JOURNAL_CACHE_MAX_BYTES"); maxStr != "" {
+		if maxBytes, err := strconv.ParseInt(maxStr, 10, 64); err == nil {
+			c.CacheMaxBytes = maxBytes
+		}
+	}
+
+	if ttlStr := os.Getenv("LL_JOURNAL_CACHE_TTL_SECONDS"); ttlStr != "" {
+		if ttl, err := strconv.Atoi(ttlStr); err == nil {
+			c.CacheTTLSeconds = ttl
+		}
+	}
+
+	if url := os.Getenv("LL_JOURNAL_REDIS_URL"); url != "" {
+		c.RedisURL = url
+	}
+
+	if mode := os.Getenv("LL_JOURNAL_AUTH_MODE"); mode != "" {
+		c.AuthMode = mode
+	}
+
+	if secret := os.Getenv("LL_JOURNAL_AUTH_SECRET"); secret != "" {
+		c.AuthSecret = secret
+	}
+
+	if path := os.Getenv("LL_JOURNAL_AUTH_JWKS_FILE"); path != "" {
+		c.AuthJWKSFile = path
+	}
+
+	if issuer := os.Getenv("LL_JOURNAL_AUTH_ISSUER"); issuer != "" {
+		c.AuthIssuer = issuer
+	}
+
+	if audience := os.Getenv("LL_JOURNAL_AUTH_AUDIENCE"); audience != "" {
+		c.AuthAudience = audience
+	}
+
+	if skewStr := os.Getenv("LL_JOURNAL_AUTH_MAX_SKEW_SECONDS"); skewStr != "" {
+		if skew, err := strconv.Atoi(skewStr); err == nil {
+			c.AuthMaxSkewSeconds = skew
+		}
+	}
+
+	if path := os.Getenv("LL_JOURNAL_TLS_CERT_FILE"); path != "" {
+		c.TLSCertFile = path
+	}
+
+	if path := os.Getenv("LL_JOURNAL_TLS_KEY_FILE"); path != "" {
+		c.TLSKeyFile = path
+	}
+
+	if path := os.Getenv("LL_JOURNAL_TLS_CLIENT_CA_FILE"); path != "" {
+		c.TLSClientCAFile = path
+	}
+
+	if clients := os.Getenv("LL_JOURNAL_TLS_ALLOWED_CLIENTS"); clients != "" {
+		c.TLSAllowedClients = strings.Split(clients, ",")
+	}
+
+	if admins := os.Getenv("LL_JOURNAL_ADMIN_SUBS"); admins != "" {
+		c.AdminSubs = strings.Split(admins, ",")
+	}
+
+	if networks := os.Getenv("LL_JOURNAL_WEBHOOK_ALLOWED_NETWORKS"); networks != "" {
+		c.WebhookAllowedNetworks = strings.Split(networks, ",")
+	}
+
+	if attemptsStr := os.Getenv("LL_JOURNAL_WEBHOOK_MAX_ATTEMPTS"); attemptsStr != "" {
+		if attempts, err := strconv.Atoi(attemptsStr); err == nil {
+			c.WebhookMaxAttempts = attempts
+		}
+	}
+
+	if timeoutStr := os.Getenv("LL_JOURNAL_WEBHOOK_TIMEOUT_SECONDS"); timeoutStr != "" {
+		if timeout, err := strconv.Atoi(timeoutStr); err == nil {
+			c.WebhookTimeoutSeconds = timeout
+		}
+	}
+
+	if natsURL := os.Getenv("LL_JOURNAL_NATS_URL"); natsURL != "" {
+		c.NATSURL = natsURL
+	}
+
+	if subject := os.Getenv("LL_JOURNAL_NATS_SUBJECT"); subject != "" {
+		c.NATSSubject = subject
+	}
+
+	if caFile := os.Getenv("LL_JOURNAL_NATS_CA_FILE"); caFile != "" {
+		c.NATSCAFile = caFile
+	}
+
+	if channel := os.Getenv("LL_JOURNAL_EVENTS_NOTIFY_CHANNEL"); channel != "" {
+		c.EventsNotifyChannel = channel
+	}
+
+	if checkpointStr := os.Getenv("LL_JOURNAL_COLLAB_CHECKPOINT_SECONDS"); checkpointStr != "" {
+		if checkpoint, err := strconv.Atoi(checkpointStr); err == nil {
+			c.CollabCheckpointSeconds = checkpoint
+		}
+	}
+	if coalesceStr := os.Getenv("LL_JOURNAL_COMMIT_COALESCE_SECONDS"); coalesceStr != "" {
+		if coalesce, err := strconv.Atoi(coalesceStr); err == nil {
+			c.CommitCoalesceSeconds = coalesce
+		}
+	}
 }
 
 // LoadFromJSON loads configuration from JSON file
@@ -170,6 +360,114 @@ func (c *Config) mergeFromJSON(jsonConfig *Config) {
 	if os.Getenv("LL_JOURNAL_LOG_LEVEL") == "" && jsonConfig.LogLevel != "" {
 		c.LogLevel = jsonConfig.LogLevel
 	}
+
+	if os.Getenv("LL_JOURNAL_ENCRYPTION_MASTER_KEY") == "" && jsonConfig.EncryptionMasterKey != "" {
+		c.EncryptionMasterKey = jsonConfig.EncryptionMasterKey
+	}
+
+	if os.Getenv("LL_JOURNAL_ENCRYPTION_KEYRING_FILE") == "" && jsonConfig.EncryptionKeyringFile != "" {
+		c.EncryptionKeyringFile = jsonConfig.EncryptionKeyringFile
+	}
+
+	if os.Getenv("LL_JOURNAL_ATTACHMENT_MAX_BYTES") == "" && jsonConfig.AttachmentMaxBytes != 0 {
+		c.AttachmentMaxBytes = jsonConfig.AttachmentMaxBytes
+	}
+
+	if os.Getenv("LL_JOURNAL_PRESIGN_EXPIRY_SECONDS") == "" && jsonConfig.PresignExpirySeconds != 0 {
+		c.PresignExpirySeconds = jsonConfig.PresignExpirySeconds
+	}
+
+	if os.Getenv("LL_JOURNAL_CACHE_MAX_BYTES") == "" && jsonConfig.CacheMaxBytes != 0 {
+		c.CacheMaxBytes = jsonConfig.CacheMaxBytes
+	}
+
+	if os.Getenv("LL_JOURNAL_CACHE_TTL_SECONDS") == "" && jsonConfig.CacheTTLSeconds != 0 {
+		c.CacheTTLSeconds = jsonConfig.CacheTTLSeconds
+	}
+
+	if os.Getenv("LL_JOURNAL_REDIS_URL") == "" && jsonConfig.RedisURL != "" {
+		c.RedisURL = jsonConfig.RedisURL
+	}
+
+	if os.Getenv("LL_JOURNAL_AUTH_MODE") == "" && jsonConfig.AuthMode != "" {
+		c.AuthMode = jsonConfig.AuthMode
+	}
+
+	if os.Getenv("LL_JOURNAL_AUTH_SECRET") == "" && jsonConfig.AuthSecret != "" {
+		c.AuthSecret = jsonConfig.AuthSecret
+	}
+
+	if os.Getenv("LL_JOURNAL_AUTH_JWKS_FILE") == "" && jsonConfig.AuthJWKSFile != "" {
+		c.AuthJWKSFile = jsonConfig.AuthJWKSFile
+	}
+
+	if os.Getenv("LL_JOURNAL_AUTH_ISSUER") == "" && jsonConfig.AuthIssuer != "" {
+		c.AuthIssuer = jsonConfig.AuthIssuer
+	}
+
+	if os.Getenv("LL_JOURNAL_AUTH_AUDIENCE") == "" && jsonConfig.AuthAudience != "" {
+		c.AuthAudience = jsonConfig.AuthAudience
+	}
+
+	if os.Getenv("LL_JOURNAL_AUTH_MAX_SKEW_SECONDS") == "" && jsonConfig.AuthMaxSkewSeconds != 0 {
+		c.AuthMaxSkewSeconds = jsonConfig.AuthMaxSkewSeconds
+	}
+
+	if os.Getenv("LL_JOURNAL_TLS_CERT_FILE") == "" && jsonConfig.TLSCertFile != "" {
+		c.TLSCertFile = jsonConfig.TLSCertFile
+	}
+
+	if os.Getenv("LL_JOURNAL_TLS_KEY_FILE") == "" && jsonConfig.TLSKeyFile != "" {
+		c.TLSKeyFile = jsonConfig.TLSKeyFile
+	}
+
+	if os.Getenv("LL_JOURNAL_TLS_CLIENT_CA_FILE") == "" && jsonConfig.TLSClientCAFile != "" {
+		c.TLSClientCAFile = jsonConfig.TLSClientCAFile
+	}
+
+	if os.Getenv("LL_JOURNAL_TLS_ALLOWED_CLIENTS") == "" && len(jsonConfig.TLSAllowedClients) > 0 {
+		c.TLSAllowedClients = jsonConfig.TLSAllowedClients
+	}
+
+	if os.Getenv("LL_JOURNAL_ADMIN_SUBS") == "" && len(jsonConfig.AdminSubs) > 0 {
+		c.AdminSubs = jsonConfig.AdminSubs
+	}
+
+	if os.Getenv("LL_JOURNAL_WEBHOOK_ALLOWED_NETWORKS") == "" && len(jsonConfig.WebhookAllowedNetworks) > 0 {
+		c.WebhookAllowedNetworks = jsonConfig.WebhookAllowedNetworks
+	}
+
+	if os.Getenv("LL_JOURNAL_WEBHOOK_MAX_ATTEMPTS") == "" && jsonConfig.WebhookMaxAttempts != 0 {
+		c.WebhookMaxAttempts = jsonConfig.WebhookMaxAttempts
+	}
+
+	if os.Getenv("LL_JOURNAL_WEBHOOK_TIMEOUT_SECONDS") == "" && jsonConfig.WebhookTimeoutSeconds != 0 {
+		c.WebhookTimeoutSeconds = jsonConfig.WebhookTimeoutSeconds
+	}
+
+	if os.Getenv("LL_JOURNAL_NATS_URL") == "" && jsonConfig.NATSURL != "" {
+		c.NATSURL = jsonConfig.NATSURL
+	}
+
+	if os.Getenv("LL_JOURNAL_NATS_SUBJECT") == "" && jsonConfig.NATSSubject != "" {
+		c.NATSSubject = jsonConfig.NATSSubject
+	}
+
+	if os.Getenv("LL_JOURNAL_NATS_CA_FILE") == "" && jsonConfig.NATSCAFile != "" {
+		c.NATSCAFile = jsonConfig.NATSCAFile
+	}
+
+	if os.Getenv("LL_JOURNAL_EVENTS_NOTIFY_CHANNEL") == "" && jsonConfig.EventsNotifyChannel != "" {
+		c.EventsNotifyChannel = jsonConfig.EventsNotifyChannel
+	}
+
+	if os.Getenv("LL_JOURNAL_COLLAB_CHECKPOINT_SECONDS") == "" && jsonConfig.CollabCheckpointSeconds != 0 {
+		c.CollabCheckpointSeconds = jsonConfig.CollabCheckpointSeconds
+	}
+
+	if os.Getenv("LL_JOURNAL_COMMIT_COALESCE_SECONDS") == "" && jsonConfig.CommitCoalesceSeconds != 0 {
+		c.CommitCoalesceSeconds = jsonConfig.CommitCoalesceSeconds
+	}
 }
 
 // SocketAddr returns the socket address string
@@ -0,0 +1,277 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+// Package encryption implements envelope encryption for entry content.
+//
+// Every user has a versioned AES-256 data key, stored in Postgres wrapped by
+// a master key. Content is sealed with AES-256-GCM and framed as:
+//
+//	"LLJE" | format (1 byte) | data key version (uint32, big endian) | nonce (12 bytes) | ciphertext+tag
+//
+// with the owning user sub as additional authenticated data. The format only
+// uses standard primitives so a client holding the unwrapped data key can
+// decrypt blobs itself.
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+const (
+	magic       = "LLJE"
+	keySize     = 32
+	formatV1    = 1
+	headerSize  = len(magic) + 1 + 4
+	minBlobSize = headerSize + 12 + 16
+	cacheKeyFmt = "%s:%d"
+)
+
+type Envelope struct {
+	store   *store.Store
+	wrapper KeyWrapper
+
+	mu   sync.Mutex
+	keys map[string][]byte
+}
+
+func New(st *store.Store, wrapper KeyWrapper) *Envelope {
+	return &Envelope{
+		store:   st,
+		wrapper: wrapper,
+		keys:    make(map[string][]byte),
+	}
+}
+
+// IsEncrypted reports whether a blob carries the envelope header
+func IsEncrypted(blob []byte) bool {
+	return len(blob) >= minBlobSize && bytes.HasPrefix(blob, []byte(magic)) && blob[len(magic)] == formatV1
+}
+
+// Encrypt seals content with the user's active data key, creating one if needed
+func (e *Envelope) Encrypt(ctx context.Context, userSub string, plaintext []byte) ([]byte, error) {
+	version, key, err := e.activeKey(ctx, userSub)
+	if err != nil {
+		return nil, err
+	}
+
+	return sealBlob(version, key, userSub, plaintext)
+}
+
+// sealBlob seals content with a data key and frames it with the header
+func sealBlob(version int, key []byte, userSub string, plaintext []byte) ([]byte, error) {
+	sealed, err := seal(key, plaintext, []byte(userSub))
+	if err != nil {
+		return nil, err
+	}
+
+	blob := make([]byte, 0, headerSize+len(sealed))
+	blob = append(blob, magic...)
+	blob = append(blob, formatV1)
+	blob = binary.BigEndian.AppendUint32(blob, uint32(version))
+	return append(blob, sealed...), nil
+}
+
+// Decrypt opens a blob produced by Encrypt. Blobs written before encryption
+// was enabled are returned unchanged.
+func (e *Envelope) Decrypt(ctx context.Context, userSub string, blob []byte) ([]byte, error) {
+	if !IsEncrypted(blob) {
+		return blob, nil
+	}
+
+	version := int(binary.BigEndian.Uint32(blob[len(magic)+1 : headerSize]))
+	key, err := e.dataKey(ctx, userSub, version)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := open(key, blob[headerSize:], []byte(userSub))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt content: %w", err)
+	}
+	return plaintext, nil
+}
+
+// RotateDataKey generates a new active data key for a user. Older versions
+// are kept so existing blobs and git history remain readable.
+func (e *Envelope) RotateDataKey(ctx context.Context, userSub string) (int, error) {
+	version, _, err := e.createKey(ctx, userSub)
+	return version, err
+}
+
+// RewrapDataKeys rewraps every data key under the active master key and
+// returns how many were changed
+func (e *Envelope) RewrapDataKeys(ctx context.Context) (int, error) {
+	keys, err := e.store.ListDataKeys(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list data keys: %w", err)
+	}
+
+	rewrapped := 0
+	for _, dk := range keys {
+		if dk.MasterKeyID == e.wrapper.ActiveKeyID() {
+			continue
+		}
+		plain, err := e.wrapper.Unwrap(dk.WrappedKey, dk.MasterKeyID)
+		if err != nil {
+			return rewrapped, fmt.Errorf("failed to unwrap data key %s v%d: %w", dk.UserSub, dk.Version, err)
+		}
+		wrapped, keyID, err := e.wrapper.Wrap(plain)
+		if err != nil {
+			return rewrapped, fmt.Errorf("failed to wrap data key: %w", err)
+		}
+		if err := e.store.RewrapDataKey(ctx, dk.ID, wrapped, keyID); err != nil {
+			return rewrapped, fmt.Errorf("failed to save rewrapped data key: %w", err)
+		}
+		rewrapped++
+	}
+	return rewrapped, nil
+}
+
+func (e *Envelope) activeKey(ctx context.Context, userSub string) (int, []byte, error) {
+	dk, err := e.store.GetActiveDataKey(ctx, userSub)
+	if errors.Is(err, sql.ErrNoRows) {
+		return e.createFirstKey(ctx, userSub)
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to load data key: %w", err)
+	}
+	key, err := e.unwrap(dk)
+	return dk.Version, key, err
+}
+
+func (e *Envelope) dataKey(ctx context.Context, userSub string, version int) ([]byte, error) {
+	e.mu.Lock()
+	key, ok := e.keys[fmt.Sprintf(cacheKeyFmt, userSub, version)]
+	e.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	dk, err := e.store.GetDataKey(ctx, userSub, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data key v%d: %w", version, err)
+	}
+	return e.unwrap(dk)
+}
+
+func (e *Envelope) createKey(ctx context.Context, userSub string) (int, []byte, error) {
+	key, dk, err := e.newKey(userSub)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	dk, err = e.store.CreateDataKey(ctx, dk)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to save data key: %w", err)
+	}
+
+	e.remember(userSub, dk.Version, key)
+	return dk.Version, key, nil
+}
+
+// createFirstKey creates a user's first data key. If another request
+// created one first, that one is used instead.
+func (e *Envelope) createFirstKey(ctx context.Context, userSub string) (int, []byte, error) {
+	key, dk, err := e.newKey(userSub)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	dk, created, err := e.store.CreateFirstDataKey(ctx, dk)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to save data key: %w", err)
+	}
+	if !created {
+		if dk, err = e.store.GetActiveDataKey(ctx, userSub); err != nil {
+			return 0, nil, fmt.Errorf("failed to load data key: %w", err)
+		}
+		key, err := e.unwrap(dk)
+		return dk.Version, key, err
+	}
+
+	e.remember(userSub, dk.Version, key)
+	return dk.Version, key, nil
+}
+
+// newKey generates a data key and wraps it under the active master key
+func (e *Envelope) newKey(userSub string) ([]byte, store.DataKey, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, store.DataKey{}, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	wrapped, keyID, err := e.wrapper.Wrap(key)
+	if err != nil {
+		return nil, store.DataKey{}, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	return key, store.DataKey{UserSub: userSub, WrappedKey: wrapped, MasterKeyID: keyID}, nil
+}
+
+func (e *Envelope) unwrap(dk store.DataKey) ([]byte, error) {
+	e.mu.Lock()
+	key, ok := e.keys[fmt.Sprintf(cacheKeyFmt, dk.UserSub, dk.Version)]
+	e.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	key, err := e.wrapper.Unwrap(dk.WrappedKey, dk.MasterKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	e.remember(dk.UserSub, dk.Version, key)
+	return key, nil
+}
+
+func (e *Envelope) remember(userSub string, version int, key []byte) {
+	e.mu.Lock()
+	e.keys[fmt.Sprintf(cacheKeyFmt, userSub, version)] = key
+	e.mu.Unlock()
+}
+
+// seal encrypts with AES-256-GCM and returns nonce || ciphertext
+func seal(key, plaintext, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// open reverses seal
+func open(key, sealed, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, aad)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,145 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+)
+
+var testDataKey = bytes.Repeat([]byte{7}, keySize)
+
+// testEnvelope returns an envelope with version 1 of user-1's data key
+// cached, so Decrypt never reaches the store
+func testEnvelope() *Envelope {
+	e := New(nil, nil)
+	e.remember("user-1", 1, testDataKey)
+	return e
+}
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		plaintext []byte
+	}{
+		{name: "empty", plaintext: []byte{}},
+		{name: "text", plaintext: []byte("# Tuesday\n\nWalked to the harbour.")},
+		{name: "binary", plaintext: bytes.Repeat([]byte{0, 0xff}, 4096)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blob, err := sealBlob(1, testDataKey, "user-1", tt.plaintext)
+			if err != nil {
+				t.Fatalf("sealBlob() error = %v", err)
+			}
+			if !IsEncrypted(blob) {
+				t.Fatal("IsEncrypted() = false for a sealed blob")
+			}
+			got, err := testEnvelope().Decrypt(context.Background(), "user-1", blob)
+			if err != nil {
+				t.Fatalf("Decrypt() error = %v", err)
+			}
+			if !bytes.Equal(got, tt.plaintext) {
+				t.Errorf("Decrypt() = %q, want %q", got, tt.plaintext)
+			}
+		})
+	}
+}
+
+func TestEnvelopeRejectsTampering(t *testing.T) {
+	blob, err := sealBlob(1, testDataKey, "user-1", []byte("secret entry"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	flip := func(i int) []byte {
+		tampered := append([]byte(nil), blob...)
+		tampered[i] ^= 1
+		return tampered
+	}
+
+	tests := []struct {
+		name    string
+		userSub string
+		blob    []byte
+	}{
+		{name: "nonce", userSub: "user-1", blob: flip(headerSize)},
+		{name: "ciphertext", userSub: "user-1", blob: flip(headerSize + 12)},
+		{name: "tag", userSub: "user-1", blob: flip(len(blob) - 1)},
+		{name: "truncated", userSub: "user-1", blob: blob[:len(blob)-1]},
+		{name: "other user", userSub: "user-2", blob: blob},
+	}
+	e := testEnvelope()
+	e.remember("user-2", 1, testDataKey)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := e.Decrypt(context.Background(), tt.userSub, tt.blob); err == nil {
+				t.Errorf("Decrypt() = %q, want an error", got)
+			}
+		})
+	}
+}
+
+func TestEnvelopePlaintextPassthrough(t *testing.T) {
+	tests := []string{"", "plain markdown", "LLJE but too short"}
+	for _, plaintext := range tests {
+		got, err := testEnvelope().Decrypt(context.Background(), "user-1", []byte(plaintext))
+		if err != nil || string(got) != plaintext {
+			t.Errorf("Decrypt(%q) = %q, %v; want it unchanged", plaintext, got, err)
+		}
+	}
+}
+
+func TestKeyringWrap(t *testing.T) {
+	ring, err := NewMasterKey("k1", base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, keySize)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrapped, keyID, err := ring.Wrap(testDataKey)
+	if err != nil || keyID != "k1" {
+		t.Fatalf("Wrap() = %q, %v", keyID, err)
+	}
+
+	tests := []struct {
+		name    string
+		wrapped []byte
+		keyID   string
+		wantErr bool
+	}{
+		{name: "valid", wrapped: wrapped, keyID: "k1"},
+		{name: "unknown key", wrapped: wrapped, keyID: "k2", wantErr: true},
+		{name: "tampered", wrapped: append(append([]byte(nil), wrapped[:len(wrapped)-1]...), wrapped[len(wrapped)-1]^1), keyID: "k1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ring.Unwrap(tt.wrapped, tt.keyID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unwrap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !bytes.Equal(key, testDataKey) {
+				t.Errorf("Unwrap() = %x, want %x", key, testDataKey)
+			}
+		})
+	}
+}
+
+func TestNewMasterKeyRejectsBadKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{name: "not base64", encoded: "not base64!"},
+		{name: "short", encoded: base64.StdEncoding.EncodeToString(make([]byte, 16))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMasterKey("k1", tt.encoded); err == nil {
+				t.Error("NewMasterKey() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package encryption
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// KeyWrapper wraps and unwraps per-user data keys with a master key
+type KeyWrapper interface {
+	// ActiveKeyID returns the ID of the master key used for new wraps
+	ActiveKeyID() string
+	// Wrap encrypts a data key with the active master key
+	Wrap(dataKey []byte) (wrapped []byte, keyID string, err error)
+	// Unwrap decrypts a data key with the master key it was wrapped by
+	Unwrap(wrapped []byte, keyID string) ([]byte, error)
+}
+
+// Keyring is a local stand-in for a KMS: a set of named master keys, one of them active
+type Keyring struct {
+	active string
+	keys   map[string][]byte
+}
+
+type keyringFile struct {
+	Active string            `json:"active"`
+	Keys   map[string]string `json:"keys"`
+}
+
+// NewMasterKey returns a keyring holding a single master key, as configured
+// through LL_JOURNAL_ENCRYPTION_MASTER_KEY (base64-encoded, 32 bytes)
+func NewMasterKey(id, encodedKey string) (*Keyring, error) {
+	key, err := decodeKey(encodedKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Keyring{active: id, keys: map[string][]byte{id: key}}, nil
+}
+
+// LoadKeyring loads a keyring from a JSON file of the form
+// {"active": "k2", "keys": {"k1": "<base64>", "k2": "<base64>"}}.
+// Retired keys stay in the file until every data key has been rewrapped.
+func LoadKeyring(path string) (*Keyring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keyring file: %w", err)
+	}
+
+	var file keyringFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse keyring file: %w", err)
+	}
+
+	ring := &Keyring{active: file.Active, keys: make(map[string][]byte, len(file.Keys))}
+	for id, encoded := range file.Keys {
+		key, err := decodeKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("master key %s: %w", id, err)
+		}
+		ring.keys[id] = key
+	}
+	if _, ok := ring.keys[ring.active]; !ok {
+		return nil, fmt.Errorf("active master key %q not found in keyring", ring.active)
+	}
+	return ring, nil
+}
+
+func (k *Keyring) ActiveKeyID() string {
+	return k.active
+}
+
+func (k *Keyring) Wrap(dataKey []byte) ([]byte, string, error) {
+	wrapped, err := seal(k.keys[k.active], dataKey, []byte(k.active))
+	if err != nil {
+		return nil, "", err
+	}
+	return wrapped, k.active, nil
+}
+
+func (k *Keyring) Unwrap(wrapped []byte, keyID string) ([]byte, error) {
+	key, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("master key %q not found in keyring", keyID)
+	}
+	return open(key, wrapped, []byte(keyID))
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 key: %w", err)
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", keySize, len(key))
+	}
+	return key, nil
+}
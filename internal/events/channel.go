@@ -0,0 +1,77 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package events
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+)
+
+// Channel fans events out to in-process subscribers. Publishing never
+// blocks: a subscriber whose buffer is full misses the event.
+type Channel struct {
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+
+	dropped atomic.Int64
+}
+
+// Subscription receives events on C until it is closed
+type Subscription struct {
+	C <-chan Event
+
+	ch      chan Event
+	channel *Channel
+	once    sync.Once
+}
+
+func NewChannel() *Channel {
+	return &Channel{subs: make(map[*Subscription]struct{})}
+}
+
+// Subscribe starts receiving events with room for buffer undelivered ones
+func (c *Channel) Subscribe(buffer int) *Subscription {
+	ch := make(chan Event, buffer)
+	sub := &Subscription{C: ch, ch: ch, channel: c}
+
+	c.mu.Lock()
+	c.subs[sub] = struct{}{}
+	c.mu.Unlock()
+	return sub
+}
+
+func (c *Channel) Publish(ctx context.Context, event Event) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for sub := range c.subs {
+		select {
+		case sub.ch <- event:
+		default:
+			if c.dropped.Add(1)%100 == 1 {
+				log.Printf("Warning: event subscriber is falling behind; %d events dropped", c.dropped.Load())
+			}
+		}
+	}
+	return nil
+}
+
+// Dropped returns how many events subscribers have missed
+func (c *Channel) Dropped() int64 {
+	return c.dropped.Load()
+}
+
+// Close stops the subscription and closes C
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.channel.mu.Lock()
+		delete(s.channel.subs, s)
+		s.channel.mu.Unlock()
+		close(s.ch)
+	})
+}
@@ -0,0 +1,116 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+// Package events carries domain events out of journal.Service. The service
+// publishes an Event once a change is committed; publishers hand it to an
+// in-process channel, a NATS subject or a Postgres NOTIFY channel, so
+// consumers can react without touching the service.
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Event types
+const (
+	JournalCreated = "journal.created"
+	JournalUpdated = "journal.updated"
+	JournalDeleted = "journal.deleted"
+	MemberAdded    = "member.added"
+	MemberRemoved  = "member.removed"
+	EntryCreated   = "entry.created"
+	EntryUpdated   = "entry.updated"
+	EntryDeleted   = "entry.deleted"
+	VersionCreated = "version.created"
+)
+
+// Types lists every event type the service publishes
+var Types = []string{
+	JournalCreated,
+	JournalUpdated,
+	JournalDeleted,
+	MemberAdded,
+	MemberRemoved,
+	EntryCreated,
+	EntryUpdated,
+	EntryDeleted,
+	VersionCreated,
+}
+
+// Known reports whether eventType is one of Types
+func Known(eventType string) bool {
+	for _, known := range Types {
+		if eventType == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Event describes a committed change. Entry content is never included;
+// consumers fetch it through the API if they need it.
+type Event struct {
+	ID         string                 `json:"id"`
+	Type       string                 `json:"type"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	JournalID  string                 `json:"journal_id"`
+	Actor      string                 `json:"actor"`
+	Data       map[string]interface{} `json:"data,omitempty"`
+
+	// Audience lists users the event concerns besides the journal's current
+	// members, such as the members of a journal that was just deleted
+	Audience []string `json:"-"`
+}
+
+// New creates an event with a fresh random ID
+func New(eventType, journalID, actor string, data map[string]interface{}) (Event, error) {
+	id, err := newID()
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{
+		ID:         id,
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		JournalID:  journalID,
+		Actor:      actor,
+		Data:       data,
+	}, nil
+}
+
+// Publisher delivers events to consumers. Publish is called synchronously
+// after the change is committed, so it should not block for long.
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// Multi publishes to every publisher in turn. One failing does not stop the
+// others; their errors are joined.
+type Multi []Publisher
+
+func (m Multi) Publish(ctx context.Context, event Event) error {
+	var errs []error
+	for _, p := range m {
+		if err := p.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// newID returns a random (version 4) UUID
+func newID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate event ID: %w", err)
+	}
+	buf[6] = buf[6]&0x0f | 0x40
+	buf[8] = buf[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", buf[0:4], buf[4:6], buf[6:8], buf[8:10], buf[10:16]), nil
+}
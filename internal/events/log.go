@@ -0,0 +1,123 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+const (
+	// LogChannel is the Postgres channel notified of every logged event
+	LogChannel = "ll_journal_event_log"
+
+	// logPollInterval wakes subscribers even without notifications, in case
+	// one was lost while the listener reconnected
+	logPollInterval = time.Minute
+)
+
+// Log publishes events to the event_log table and wakes local subscribers
+// when any instance logs one, via LISTEN/NOTIFY. Change streams read the
+// table, so they see events from every replica and can resume after a
+// disconnect.
+type Log struct {
+	store       *store.Store
+	databaseURL string
+
+	mu   sync.Mutex
+	subs map[chan struct{}]struct{}
+}
+
+func NewLog(store *store.Store, databaseURL string) *Log {
+	return &Log{
+		store:       store,
+		databaseURL: databaseURL,
+		subs:        make(map[chan struct{}]struct{}),
+	}
+}
+
+func (l *Log) Publish(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	if _, err := l.store.AppendEventLog(ctx, store.LoggedEvent{
+		EventID:   event.ID,
+		EventType: event.Type,
+		JournalID: event.JournalID,
+		Actor:     event.Actor,
+		Payload:   string(payload),
+	}, event.Audience, LogChannel); err != nil {
+		return fmt.Errorf("failed to log event: %w", err)
+	}
+	return nil
+}
+
+// Subscribe returns a channel that receives a signal whenever new events may
+// have been logged. Signals coalesce; the subscriber reads the log to find
+// out what changed. Call the returned function to unsubscribe.
+func (l *Log) Subscribe() (<-chan struct{}, func()) {
+	wake := make(chan struct{}, 1)
+
+	l.mu.Lock()
+	l.subs[wake] = struct{}{}
+	l.mu.Unlock()
+
+	return wake, func() {
+		l.mu.Lock()
+		delete(l.subs, wake)
+		l.mu.Unlock()
+	}
+}
+
+// Listen wakes subscribers on notifications until ctx is cancelled
+func (l *Log) Listen(ctx context.Context) error {
+	listener := pq.NewListener(l.databaseURL, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("Warning: event log listener: %v", err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(LogChannel); err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", LogChannel, err)
+	}
+
+	ticker := time.NewTicker(logPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-listener.Notify:
+			// A nil notification means the connection was re-established,
+			// and events may have been missed meanwhile; wake either way
+		case <-ticker.C:
+			go listener.Ping()
+		}
+		l.wake()
+	}
+}
+
+func (l *Log) wake() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for wake := range l.subs {
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,417 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package events
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	natsDialTimeout = 2 * time.Second
+	natsIOTimeout   = 5 * time.Second
+
+	// natsQueueSize is how many events may wait for the server before new
+	// ones are dropped
+	natsQueueSize = 4096
+
+	// natsBatchSize is the most messages written before waiting for the
+	// server to acknowledge them
+	natsBatchSize = 256
+
+	// natsMinReconnectWait and natsMaxReconnectWait bound the backoff
+	// between connection attempts
+	natsMinReconnectWait = 250 * time.Millisecond
+	natsMaxReconnectWait = 30 * time.Second
+
+	// natsCloseTimeout bounds flushing the queue on Close
+	natsCloseTimeout = 5 * time.Second
+)
+
+// NATS publishes events to a NATS-compatible server (NATS, or anything that
+// speaks its text protocol) on the subject <prefix>.<event type>, e.g.
+// ll-journal.events.entry.created. Publish only queues the event, so a slow
+// or unreachable server never holds up a request; a background loop writes
+// the queue in batches, each confirmed by a PING, and reconnects with backoff
+// when the connection fails. Events are dropped when the queue is full, when
+// they exceed the server's max_payload, and when the server answers a batch
+// with -ERR, e.g. for a permissions violation: sending that batch again
+// would only fail the same way and hold up everything queued behind it.
+type NATS struct {
+	addr   string
+	user   string
+	pass   string
+	token  string
+	prefix string
+	tls    *tls.Config
+	useTLS bool // tls:// URL; otherwise TLS is used if the server requires it
+
+	queue   chan natsMessage
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+	dropped atomic.Int64
+
+	// Owned by the run loop
+	conn       net.Conn
+	r          *bufio.Reader
+	maxPayload int // from the server's INFO; 0 if it gave none
+}
+
+// natsServerError is an -ERR the server answered a batch with
+type natsServerError struct {
+	reason string
+}
+
+func (e *natsServerError) Error() string {
+	return "nats: " + e.reason
+}
+
+type natsMessage struct {
+	subject string
+	payload []byte
+}
+
+// NewNATS parses a nats:// or tls://[user:pass@|token@]host:port URL and
+// starts publishing in the background. caFile optionally names a PEM bundle
+// to verify the server with instead of the system roots.
+func NewNATS(rawURL, subjectPrefix, caFile string) (*NATS, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nats url: %w", err)
+	}
+	if u.Scheme != "nats" && u.Scheme != "tls" {
+		return nil, fmt.Errorf("invalid nats url scheme: %s", u.Scheme)
+	}
+	if strings.ContainsAny(subjectPrefix, " \t\r\n") || subjectPrefix == "" {
+		return nil, fmt.Errorf("invalid nats subject prefix: %q", subjectPrefix)
+	}
+
+	n := &NATS{
+		addr:   u.Host,
+		prefix: subjectPrefix,
+		useTLS: u.Scheme == "tls",
+		tls:    &tls.Config{MinVersion: tls.VersionTLS12, ServerName: u.Hostname()},
+		queue:  make(chan natsMessage, natsQueueSize),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	if u.Port() == "" {
+		n.addr = net.JoinHostPort(u.Hostname(), "4222")
+	}
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			n.user, n.pass = u.User.Username(), pass
+		} else {
+			n.token = u.User.Username()
+		}
+	}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read nats CA file: %w", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("invalid nats CA file: no certificates in %s", caFile)
+		}
+		n.tls.RootCAs = roots
+	}
+
+	go n.run()
+	return n, nil
+}
+
+// Publish queues the event. It never blocks; when the queue is full the
+// event is dropped and counted.
+func (n *NATS) Publish(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	select {
+	case n.queue <- natsMessage{subject: n.prefix + "." + event.Type, payload: payload}:
+	default:
+		if n.dropped.Add(1)%100 == 1 {
+			log.Printf("Warning: nats publisher is falling behind; %d events dropped", n.dropped.Load())
+		}
+	}
+	return nil
+}
+
+// Dropped returns how many events were dropped because the queue was full
+func (n *NATS) Dropped() int64 {
+	return n.dropped.Load()
+}
+
+// Close stops publishing, first trying briefly to send what is queued
+func (n *NATS) Close() error {
+	n.once.Do(func() { close(n.stop) })
+	<-n.done
+	return nil
+}
+
+// run writes queued messages until Close. A batch that fails is kept and
+// sent again once the connection is back.
+func (n *NATS) run() {
+	defer close(n.done)
+	defer n.close()
+
+	var batch []natsMessage
+	wait := natsMinReconnectWait
+	for {
+		if len(batch) == 0 {
+			select {
+			case msg := <-n.queue:
+				batch = append(batch, msg)
+			case <-n.stop:
+				n.flush(batch)
+				return
+			}
+		}
+		batch = n.fill(batch)
+
+		// An idle connection may have been dropped by the server for missing
+		// its PINGs; retry once on a fresh one. The server may then see some
+		// messages twice, so delivery is at least once.
+		reused := n.conn != nil
+		err := n.send(batch)
+		var rejected *natsServerError
+		if err != nil && reused && !errors.As(err, &rejected) {
+			err = n.send(batch)
+		}
+		if errors.As(err, &rejected) {
+			n.drop(batch, err)
+			batch = batch[:0]
+			continue
+		}
+		if err != nil {
+			log.Printf("Warning: failed to publish to nats, retrying in %s: %v", wait, err)
+			select {
+			case <-time.After(wait):
+			case <-n.stop:
+				n.flush(batch)
+				return
+			}
+			wait = min(wait*2, natsMaxReconnectWait)
+			continue
+		}
+		batch = batch[:0]
+		wait = natsMinReconnectWait
+	}
+}
+
+// fill tops the batch up from the queue without waiting
+func (n *NATS) fill(batch []natsMessage) []natsMessage {
+	for len(batch) < natsBatchSize {
+		select {
+		case msg := <-n.queue:
+			batch = append(batch, msg)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// flush sends the batch and everything queued, giving up at the first
+// failure or after natsCloseTimeout
+func (n *NATS) flush(batch []natsMessage) {
+	deadline := time.Now().Add(natsCloseTimeout)
+	for time.Now().Before(deadline) {
+		batch = n.fill(batch)
+		if len(batch) == 0 {
+			return
+		}
+		err := n.send(batch)
+		var rejected *natsServerError
+		if errors.As(err, &rejected) {
+			n.drop(batch, err)
+		} else if err != nil {
+			log.Printf("Warning: failed to publish to nats on close; %d events lost: %v", len(batch)+len(n.queue), err)
+			return
+		}
+		batch = batch[:0]
+	}
+}
+
+// drop gives up on a batch the server rejected. Messages before the one it
+// objected to may have been delivered, so the batch is not split and retried.
+func (n *NATS) drop(batch []natsMessage, err error) {
+	n.dropped.Add(int64(len(batch)))
+	log.Printf("Warning: nats rejected a batch of %d events, dropping it: %v", len(batch), err)
+}
+
+// send writes a batch and waits for the server's PONG, connecting first if
+// needed. Messages over the server's max_payload are dropped rather than
+// sent, since the server would close the connection over them. The
+// connection is closed on any error; an -ERR is returned as a
+// *natsServerError.
+func (n *NATS) send(batch []natsMessage) error {
+	if n.conn == nil {
+		if err := n.connect(); err != nil {
+			return err
+		}
+	}
+	n.conn.SetDeadline(time.Now().Add(natsIOTimeout))
+
+	w := bufio.NewWriter(n.conn)
+	for _, msg := range batch {
+		if n.maxPayload > 0 && len(msg.payload) > n.maxPayload {
+			n.dropped.Add(1)
+			log.Printf("Warning: dropping %s event of %d bytes; nats allows %d", msg.subject, len(msg.payload), n.maxPayload)
+			continue
+		}
+		fmt.Fprintf(w, "PUB %s %d\r\n", msg.subject, len(msg.payload))
+		w.Write(msg.payload)
+		w.WriteString("\r\n")
+	}
+	w.WriteString("PING\r\n")
+	if err := w.Flush(); err != nil {
+		n.close()
+		return err
+	}
+	if err := n.awaitPong(); err != nil {
+		n.close()
+		return err
+	}
+	return nil
+}
+
+func (n *NATS) connect() error {
+	dialer := net.Dialer{Timeout: natsDialTimeout}
+	conn, err := dialer.Dial("tcp", n.addr)
+	if err != nil {
+		return err
+	}
+	n.conn = conn
+	n.r = bufio.NewReader(conn)
+	n.conn.SetDeadline(time.Now().Add(natsIOTimeout))
+
+	// The server opens with INFO, in plain text even when TLS follows
+	line, err := n.readLine()
+	if err != nil {
+		n.close()
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		n.close()
+		return fmt.Errorf("unexpected nats greeting: %q", line)
+	}
+	var info struct {
+		TLSRequired bool `json:"tls_required"`
+		MaxPayload  int  `json:"max_payload"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), &info); err != nil {
+		n.close()
+		return fmt.Errorf("invalid nats greeting: %w", err)
+	}
+	n.maxPayload = info.MaxPayload
+
+	if n.useTLS || info.TLSRequired {
+		tlsConn := tls.Client(conn, n.tls)
+		if err := tlsConn.Handshake(); err != nil {
+			n.close()
+			return fmt.Errorf("nats tls handshake failed: %w", err)
+		}
+		n.conn = tlsConn
+		n.r = bufio.NewReader(tlsConn)
+	} else if n.user != "" || n.token != "" {
+		log.Printf("Warning: sending nats credentials without TLS; use a tls:// URL")
+	}
+
+	options := map[string]interface{}{
+		"verbose":      false,
+		"pedantic":     false,
+		"tls_required": n.useTLS || info.TLSRequired,
+		"name":         "ll-journal",
+		"lang":         "go",
+		"version":      "1.0",
+	}
+	if n.user != "" {
+		options["user"] = n.user
+		options["pass"] = n.pass
+	}
+	if n.token != "" {
+		options["auth_token"] = n.token
+	}
+	connect, err := json.Marshal(options)
+	if err != nil {
+		n.close()
+		return err
+	}
+	if _, err := fmt.Fprintf(n.conn, "CONNECT %s\r\nPING\r\n", connect); err != nil {
+		n.close()
+		return err
+	}
+	if err := n.awaitPong(); err != nil {
+		n.close()
+		// Not a rejected batch: refusing the connection is retried
+		return fmt.Errorf("nats refused the connection: %v", err)
+	}
+	return nil
+}
+
+// awaitPong reads until the server's PONG, answering its PINGs and
+// skipping INFO updates. An -ERR is remembered and returned once the PONG
+// arrives, or once the server closes the connection over it.
+func (n *NATS) awaitPong() error {
+	var serverErr error
+	for {
+		line, err := n.readLine()
+		if err != nil {
+			if serverErr != nil {
+				return serverErr
+			}
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return serverErr
+		case line == "PING":
+			if _, err := n.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case line == "+OK", strings.HasPrefix(line, "INFO "):
+		case strings.HasPrefix(line, "-ERR"):
+			if serverErr == nil {
+				serverErr = &natsServerError{reason: strings.TrimSpace(strings.TrimPrefix(line, "-ERR"))}
+			}
+		default:
+			return fmt.Errorf("unexpected nats reply: %q", line)
+		}
+	}
+}
+
+func (n *NATS) readLine() (string, error) {
+	line, err := n.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (n *NATS) close() {
+	if n.conn != nil {
+		n.conn.Close()
+	}
+	n.conn = nil
+	n.r = nil
+}
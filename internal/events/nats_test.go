@@ -0,0 +1,132 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package events
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeNATS speaks enough of the NATS protocol to accept publishes. Subjects
+// containing "denied" are answered with a permissions violation, as a real
+// server does, and the connection is kept.
+type fakeNATS struct {
+	listener   net.Listener
+	maxPayload int
+	received   chan string
+}
+
+func newFakeNATS(t *testing.T, maxPayload int) *fakeNATS {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeNATS{listener: listener, maxPayload: maxPayload, received: make(chan string, 100)}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeNATS) serve(conn net.Conn) {
+	defer conn.Close()
+	fmt.Fprintf(conn, "INFO {\"max_payload\":%d}\r\n", f.maxPayload)
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+		case fields[0] == "PING":
+			conn.Write([]byte("PONG\r\n"))
+		case fields[0] == "PUB" && len(fields) == 3:
+			size, _ := strconv.Atoi(fields[2])
+			if _, err := io.CopyN(io.Discard, r, int64(size)+2); err != nil {
+				return
+			}
+			if strings.Contains(fields[1], "denied") {
+				fmt.Fprintf(conn, "-ERR 'Permissions Violation for Publish to \"%s\"'\r\n", fields[1])
+				continue
+			}
+			f.received <- fields[1]
+		}
+	}
+}
+
+func (f *fakeNATS) expect(t *testing.T, subject string) {
+	t.Helper()
+	select {
+	case got := <-f.received:
+		if got != subject {
+			t.Fatalf("received %s, want %s", got, subject)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for %s", subject)
+	}
+}
+
+func waitDropped(t *testing.T, n *NATS, want int64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for n.Dropped() < want {
+		if time.Now().After(deadline) {
+			t.Fatalf("Dropped() = %d, want %d", n.Dropped(), want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestNATSPublish(t *testing.T) {
+	server := newFakeNATS(t, 1<<20)
+	n, err := NewNATS("nats://"+server.listener.Addr().String(), "ll", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer n.Close()
+
+	ctx := context.Background()
+	n.Publish(ctx, Event{Type: "entry.created"})
+	server.expect(t, "ll.entry.created")
+
+	// A rejected batch is dropped instead of blocking later events
+	n.Publish(ctx, Event{Type: "denied"})
+	waitDropped(t, n, 1)
+	n.Publish(ctx, Event{Type: "entry.updated"})
+	server.expect(t, "ll.entry.updated")
+}
+
+func TestNATSMaxPayload(t *testing.T) {
+	server := newFakeNATS(t, 150)
+	n, err := NewNATS("nats://"+server.listener.Addr().String(), "ll", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer n.Close()
+
+	ctx := context.Background()
+	n.Publish(ctx, Event{Type: "entry.created", Data: map[string]interface{}{"content": strings.Repeat("x", 100)}})
+	n.Publish(ctx, Event{Type: "entry.deleted"})
+	server.expect(t, "ll.entry.deleted")
+	waitDropped(t, n, 1)
+}
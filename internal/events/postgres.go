@@ -0,0 +1,42 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package events
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// maxNotifyPayload is PostgreSQL's limit on a NOTIFY payload
+const maxNotifyPayload = 8000
+
+// Postgres publishes events as JSON with pg_notify, so any client that runs
+// LISTEN on the channel receives them. Notifications are not queued for
+// clients that are not listening.
+type Postgres struct {
+	db      *sql.DB
+	channel string
+}
+
+func NewPostgres(db *sql.DB, channel string) *Postgres {
+	return &Postgres{db: db, channel: channel}
+}
+
+func (p *Postgres) Publish(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	if len(payload) > maxNotifyPayload {
+		return fmt.Errorf("event %s is too large to notify (%d bytes)", event.ID, len(payload))
+	}
+	if _, err := p.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, p.channel, string(payload)); err != nil {
+		return fmt.Errorf("failed to notify %s: %w", p.channel, err)
+	}
+	return nil
+}
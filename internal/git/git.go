@@ -6,9 +6,12 @@
 package git
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/go-git/go-git/v5"
@@ -16,8 +19,16 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
+// ErrNotHead is returned when amending a commit that is no longer the latest
+// in the repository
+var ErrNotHead = errors.New("commit is not HEAD")
+
 type Client struct {
 	rootDir string
+
+	// repoLocks holds a *sync.Mutex per repository. Writes take it so that
+	// an amend cannot rewrite a commit made after it checked HEAD.
+	repoLocks sync.Map
 }
 
 type CommitInfo struct {
@@ -86,36 +97,33 @@ func (c *Client) GetOrInitRepo(userSub string) (*git.Repository, error) {
 	return nil, fmt.Errorf("failed to open git repository: %w", err)
 }
 
+// Author identifies who made a change. Commits are always made by the
+// system; the author records the journal member behind them.
+type Author struct {
+	Name  string
+	Email string
+}
+
+// lockRepo serializes writes to a user's repository; call the returned
+// function to release it
+func (c *Client) lockRepo(userSub string) func() {
+	mu, _ := c.repoLocks.LoadOrStore(userSub, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
 // CommitFile commits a file to the repository
-func (c *Client) CommitFile(userSub, journalID, entryDate, content, commitMessage string) (string, error) {
+func (c *Client) CommitFile(userSub, journalID, entryDate, content, commitMessage string, author Author) (string, error) {
+	defer c.lockRepo(userSub)()
+
 	repo, err := c.GetOrInitRepo(userSub)
 	if err != nil {
 		return "", err
 	}
 
-	wt, err := repo.Worktree()
+	wt, err := c.stageFile(repo, userSub, journalID, entryDate, content)
 	if err != nil {
-		return "", fmt.Errorf("failed to get worktree: %w", err)
-	}
-
-	// Create directory structure if needed
-	repoPath := filepath.Join(c.rootDir, userSub)
-	entryDir := filepath.Join(repoPath, journalID)
-	if err := os.MkdirAll(entryDir, 0755); err != nil {
-		return "", fmt.Errorf("failed to create entry directory: %w", err)
-	}
-
-	// Write file
-	filePath := filepath.Join(entryDir, fmt.Sprintf("%s.md", entryDate))
-	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
-		return "", fmt.Errorf("failed to write file: %w", err)
-	}
-
-	// Add file to git
-	relativePath := filepath.Join(journalID, fmt.Sprintf("%s.md", entryDate))
-	_, err = wt.Add(relativePath)
-	if err != nil {
-		return "", fmt.Errorf("failed to add file to git: %w", err)
+		return "", err
 	}
 
 	// Check if there are changes
@@ -138,11 +146,17 @@ func (c *Client) CommitFile(userSub, journalID, entryDate, content, commitMessag
 		commitMessage = fmt.Sprintf("Entry for %s", entryDate)
 	}
 
+	now := time.Now()
 	commit, err := wt.Commit(commitMessage, &git.CommitOptions{
 		Author: &object.Signature{
+			Name:  author.Name,
+			Email: author.Email,
+			When:  now,
+		},
+		Committer: &object.Signature{
 			Name:  "LifeLogger System",
 			Email: "system@lifelogger.life",
-			When:  time.Now(),
+			When:  now,
 		},
 	})
 	if err != nil {
@@ -152,6 +166,88 @@ func (c *Client) CommitFile(userSub, journalID, entryDate, content, commitMessag
 	return commit.String(), nil
 }
 
+// AmendFile replaces the content of a file in commitHash, which must still be
+// the latest commit of the repository, and returns the amended commit. The
+// commit keeps its message and records the new author and time. HEAD is
+// checked under the repository's write lock, so no commit can slip in
+// between the check and the amend.
+func (c *Client) AmendFile(userSub, journalID, entryDate, content, commitHash string, author Author) (string, error) {
+	defer c.lockRepo(userSub)()
+
+	repo, err := c.GetOrInitRepo(userSub)
+	if err != nil {
+		return "", err
+	}
+
+	ref, err := repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	if ref.Hash().String() != commitHash {
+		return "", ErrNotHead
+	}
+	head, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	wt, err := c.stageFile(repo, userSub, journalID, entryDate, content)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	commit, err := wt.Commit(head.Message, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  author.Name,
+			Email: author.Email,
+			When:  now,
+		},
+		Committer: &object.Signature{
+			Name:  "LifeLogger System",
+			Email: "system@lifelogger.life",
+			When:  now,
+		},
+		Amend: true,
+		// The edit may have put the file back as it was before the commit
+		AllowEmptyCommits: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to amend commit: %w", err)
+	}
+
+	return commit.String(), nil
+}
+
+// stageFile writes a file into the worktree and adds it to the index
+func (c *Client) stageFile(repo *git.Repository, userSub, journalID, entryDate, content string) (*git.Worktree, error) {
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	// Create directory structure if needed
+	repoPath := filepath.Join(c.rootDir, userSub)
+	entryDir := filepath.Join(repoPath, journalID)
+	if err := os.MkdirAll(entryDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create entry directory: %w", err)
+	}
+
+	// Write file
+	filePath := filepath.Join(entryDir, fmt.Sprintf("%s.md", entryDate))
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	// Add file to git
+	relativePath := filepath.Join(journalID, fmt.Sprintf("%s.md", entryDate))
+	if _, err := wt.Add(relativePath); err != nil {
+		return nil, fmt.Errorf("failed to add file to git: %w", err)
+	}
+
+	return wt, nil
+}
+
 // GetFileContent gets the content of a file at a specific commit
 func (c *Client) GetFileContent(userSub, journalID, entryDate, commitHash string) ([]byte, error) {
 	repo, err := c.GetOrInitRepo(userSub)
@@ -193,7 +289,7 @@ func (c *Client) GetFileContent(userSub, journalID, entryDate, commitHash string
 	defer reader.Close()
 
 	content := make([]byte, file.Size)
-	_, err = reader.Read(content)
+	_, err = io.ReadFull(reader, content)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file content: %w", err)
 	}
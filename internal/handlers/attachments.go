@@ -0,0 +1,274 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/telluriancorp/ll-journal/internal/journal"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// Attachment handlers
+
+type AttachmentResponse struct {
+	ID             string `json:"id"`
+	EntryID        string `json:"entry_id"`
+	Filename       string `json:"filename"`
+	ContentType    string `json:"content_type"`
+	SizeBytes      int64  `json:"size_bytes"`
+	ChecksumSHA256 string `json:"checksum_sha256"`
+	Encrypted      bool   `json:"encrypted"`
+	Markdown       string `json:"markdown"`
+	URL            string `json:"url,omitempty"`
+	CreatedAt      string `json:"created_at"`
+}
+
+func (h *Handlers) attachmentResponse(r *http.Request, a store.Attachment) (AttachmentResponse, error) {
+	// Attachments encrypted at rest are decrypted on the way out, so they are
+	// downloaded through the service instead of straight from S3
+	url := fmt.Sprintf("/api/journals/%s/entries/%s/attachments/%s/content", a.JournalID, chi.URLParam(r, "date"), a.ID)
+	if !a.Encrypted {
+		var err error
+		url, err = h.service.AttachmentURL(r.Context(), a)
+		if err != nil {
+			return AttachmentResponse{}, err
+		}
+	}
+	return AttachmentResponse{
+		ID:             a.ID,
+		EntryID:        a.EntryID,
+		Filename:       a.Filename,
+		ContentType:    a.ContentType,
+		SizeBytes:      a.SizeBytes,
+		ChecksumSHA256: a.ChecksumSHA256,
+		Encrypted:      a.Encrypted,
+		Markdown:       journal.AttachmentMarkdown(a),
+		URL:            url,
+		CreatedAt:      a.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}, nil
+}
+
+func (h *Handlers) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	journalID := chi.URLParam(r, "journalId")
+	entryDate := chi.URLParam(r, "date")
+
+	// Leave room for the multipart envelope around the file itself
+	r.Body = http.MaxBytesReader(w, r.Body, h.service.AttachmentMaxBytes()+1<<20)
+	part, err := fileFormPart(r)
+	if err != nil {
+		http.Error(w, "file is required (multipart/form-data)", http.StatusBadRequest)
+		return
+	}
+	defer part.Close()
+
+	// The part is streamed to S3 without buffering the whole file
+	attachment, err := h.service.AddAttachment(r.Context(), userSub, journalID, entryDate,
+		part.FileName(), part.Header.Get("Content-Type"), part)
+	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if strings.Contains(err.Error(), "invalid") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response, err := h.attachmentResponse(r, attachment)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+// fileFormPart returns the multipart part named "file" without reading the
+// request body into memory or temporary files
+func fileFormPart(r *http.Request) (*multipart.Part, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == "file" {
+			return part, nil
+		}
+		part.Close()
+	}
+}
+
+func (h *Handlers) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	journalID := chi.URLParam(r, "journalId")
+	entryDate := chi.URLParam(r, "date")
+
+	attachments, err := h.service.ListAttachments(r.Context(), userSub, journalID, entryDate)
+	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, "Journal or entry not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]AttachmentResponse, len(attachments))
+	for i, a := range attachments {
+		response[i], err = h.attachmentResponse(r, a)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handlers) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	journalID := chi.URLParam(r, "journalId")
+	entryDate := chi.URLParam(r, "date")
+	attachmentID := chi.URLParam(r, "attachmentId")
+
+	attachment, err := h.service.GetAttachment(r.Context(), userSub, journalID, entryDate, attachmentID)
+	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, "Attachment not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response, err := h.attachmentResponse(r, attachment)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// ?redirect=1 sends the client straight to the presigned URL
+	if r.URL.Query().Get("redirect") != "" {
+		http.Redirect(w, r, response.URL, http.StatusFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// DownloadAttachment serves an attachment's content through the service,
+// which is the only way to read one encrypted at rest
+func (h *Handlers) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	journalID := chi.URLParam(r, "journalId")
+	entryDate := chi.URLParam(r, "date")
+	attachmentID := chi.URLParam(r, "attachmentId")
+
+	attachment, content, err := h.service.OpenAttachment(r.Context(), userSub, journalID, entryDate, attachmentID)
+	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, "Attachment not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, no-cache")
+	serveAttachment(w, r, attachment, content)
+}
+
+// serveAttachment writes an attachment's content. Uploaded files are served
+// from the API's own origin, so they are sandboxed and never sniffed.
+func serveAttachment(w http.ResponseWriter, r *http.Request, attachment store.Attachment, content []byte) {
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", attachment.Filename))
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; sandbox")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, "", attachment.CreatedAt, bytes.NewReader(content))
+}
+
+func (h *Handlers) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	journalID := chi.URLParam(r, "journalId")
+	entryDate := chi.URLParam(r, "date")
+	attachmentID := chi.URLParam(r, "attachmentId")
+
+	if err := h.service.DeleteAttachment(r.Context(), userSub, journalID, entryDate, attachmentID); err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, "Attachment not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
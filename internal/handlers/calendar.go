@@ -0,0 +1,102 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/telluriancorp/ll-journal/internal/journal"
+)
+
+// Calendar handlers
+
+type CalendarResponse struct {
+	Month   string                `json:"month"`
+	Entries int64                 `json:"entries"`
+	Words   int64                 `json:"words"`
+	Moods   []CountResponse       `json:"moods"`
+	Tags    []CountResponse       `json:"tags"`
+	Days    []CalendarDayResponse `json:"days"`
+}
+
+type CalendarDayResponse struct {
+	Date         string   `json:"date"`
+	HasEntry     bool     `json:"has_entry"`
+	EntryID      string   `json:"entry_id,omitempty"`
+	WordCount    *int32   `json:"word_count,omitempty"`
+	Mood         string   `json:"mood,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	VersionCount int64    `json:"version_count,omitempty"`
+}
+
+type CountResponse struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+func countResponses(counts []journal.Count) []CountResponse {
+	response := make([]CountResponse, len(counts))
+	for i, count := range counts {
+		response[i] = CountResponse{Value: count.Value, Count: count.Count}
+	}
+	return response
+}
+
+// GetCalendar summarises one month of a journal (?month=YYYY-MM, default the
+// current month in the user's time zone)
+func (h *Handlers) GetCalendar(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	calendar, err := h.service.Calendar(r.Context(), userSub, chi.URLParam(r, "id"), r.URL.Query().Get("month"))
+	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if strings.Contains(err.Error(), "invalid") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, "Journal not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := CalendarResponse{
+		Month:   calendar.Month,
+		Entries: calendar.Entries,
+		Words:   calendar.Words,
+		Moods:   countResponses(calendar.Moods),
+		Tags:    countResponses(calendar.Tags),
+		Days:    make([]CalendarDayResponse, len(calendar.Days)),
+	}
+	for i, day := range calendar.Days {
+		response.Days[i] = CalendarDayResponse{Date: day.Date}
+		if entry := day.Entry; entry != nil {
+			response.Days[i].HasEntry = true
+			response.Days[i].EntryID = entry.EntryID
+			response.Days[i].Mood = entry.Mood.String
+			response.Days[i].Tags = entry.Tags
+			response.Days[i].VersionCount = entry.VersionCount
+			if entry.WordCount.Valid {
+				response.Days[i].WordCount = &entry.WordCount.Int32
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
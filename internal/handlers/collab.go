@@ -0,0 +1,120 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+	"github.com/telluriancorp/ll-journal/internal/collab"
+)
+
+// Live editing handlers
+
+const (
+	// collabMaxMessageBytes bounds one client message
+	collabMaxMessageBytes = 1 << 20
+
+	collabWriteTimeout = 10 * time.Second
+	collabPongTimeout  = 60 * time.Second
+	collabPingInterval = 25 * time.Second
+)
+
+// The default origin check only accepts browsers on the API's own host
+var collabUpgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+}
+
+// CollabEntry upgrades to a WebSocket joined to the entry's live document.
+// Frames are JSON collab.Message values in both directions.
+func (h *Handlers) CollabEntry(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if !websocket.IsWebSocketUpgrade(r) {
+		http.Error(w, "WebSocket upgrade required", http.StatusUpgradeRequired)
+		return
+	}
+
+	session, err := h.service.JoinCollab(r.Context(), userSub, chi.URLParam(r, "journalId"), chi.URLParam(r, "date"))
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "forbidden"):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case strings.Contains(err.Error(), "invalid"):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case strings.Contains(err.Error(), "not found"):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Leave however the connection ends, so the room does not keep the session
+	defer session.Leave()
+
+	conn, err := collabUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already answered the request
+		return
+	}
+	defer conn.Close()
+
+	go writeCollab(conn, session.Messages())
+
+	conn.SetReadLimit(collabMaxMessageBytes)
+	conn.SetReadDeadline(time.Now().Add(collabPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(collabPongTimeout))
+	})
+
+	for {
+		var msg collab.Message
+		if err := conn.ReadJSON(&msg); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Printf("Warning: live editing connection closed: %v", err)
+			}
+			break
+		}
+		session.Handle(msg)
+	}
+}
+
+// writeCollab writes session messages and keepalive pings until the session
+// ends or a write fails
+func writeCollab(conn *websocket.Conn, messages <-chan collab.Message) {
+	ticker := time.NewTicker(collabPingInterval)
+	defer ticker.Stop()
+	defer conn.Close()
+
+	for {
+		select {
+		case msg, ok := <-messages:
+			conn.SetWriteDeadline(time.Now().Add(collabWriteTimeout))
+			if !ok {
+				// Dropped for falling behind, or left
+				conn.WriteMessage(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				return
+			}
+			if err := conn.WriteJSON(msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(collabWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// Draft handlers
+
+type SaveDraftRequest struct {
+	Content        string          `json:"content"`
+	ClientMetadata json.RawMessage `json:"client_metadata,omitempty"`
+}
+
+type PublishDraftRequest struct {
+	Force bool `json:"force,omitempty"` // publish even if the entry changed meanwhile
+}
+
+type DraftResponse struct {
+	ID             string          `json:"id"`
+	JournalID      string          `json:"journal_id"`
+	EntryDate      string          `json:"entry_date"`
+	BaseCommitHash string          `json:"base_commit_hash,omitempty"`
+	WordCount      *int32          `json:"word_count,omitempty"`
+	ClientMetadata json.RawMessage `json:"client_metadata,omitempty"`
+	Saves          int             `json:"saves"`
+	CreatedAt      string          `json:"created_at"`
+	UpdatedAt      string          `json:"updated_at"`
+	Content        *string         `json:"content,omitempty"`
+}
+
+func draftResponse(draft store.EntryDraft) DraftResponse {
+	response := DraftResponse{
+		ID:             draft.ID,
+		JournalID:      draft.JournalID,
+		EntryDate:      draft.EntryDate.Format("2006-01-02"),
+		BaseCommitHash: draft.BaseCommitHash.String,
+		Saves:          draft.Saves,
+		CreatedAt:      draft.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:      draft.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if draft.WordCount.Valid {
+		response.WordCount = &draft.WordCount.Int32
+	}
+	if draft.ClientMetadata.Valid {
+		response.ClientMetadata = json.RawMessage(draft.ClientMetadata.String)
+	}
+	return response
+}
+
+// writeDraftError maps draft errors to status codes
+func writeDraftError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "forbidden"):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case strings.Contains(err.Error(), "conflict"), strings.Contains(err.Error(), "already exists"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "invalid"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case strings.Contains(err.Error(), "not found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// SaveDraft stores the user's work in progress on a day's entry; clients can
+// call it on every autosave
+func (h *Handlers) SaveDraft(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	journalID := chi.URLParam(r, "journalId")
+	entryDate := chi.URLParam(r, "date")
+
+	var req SaveDraftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	draft, err := h.service.SaveDraft(r.Context(), userSub, journalID, entryDate, req.Content, string(req.ClientMetadata))
+	if err != nil {
+		writeDraftError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(draftResponse(draft))
+}
+
+func (h *Handlers) GetDraft(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	journalID := chi.URLParam(r, "journalId")
+	entryDate := chi.URLParam(r, "date")
+
+	draft, content, err := h.service.GetDraft(r.Context(), userSub, journalID, entryDate)
+	if err != nil {
+		writeDraftError(w, err)
+		return
+	}
+
+	response := draftResponse(draft)
+	text := string(content)
+	response.Content = &text
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handlers) ListDrafts(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	journalID := chi.URLParam(r, "id")
+
+	drafts, err := h.service.ListDrafts(r.Context(), userSub, journalID)
+	if err != nil {
+		writeDraftError(w, err)
+		return
+	}
+
+	response := make([]DraftResponse, len(drafts))
+	for i, draft := range drafts {
+		response[i] = draftResponse(draft)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handlers) DiscardDraft(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	journalID := chi.URLParam(r, "journalId")
+	entryDate := chi.URLParam(r, "date")
+
+	if err := h.service.DiscardDraft(r.Context(), userSub, journalID, entryDate); err != nil {
+		writeDraftError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PublishDraft commits the user's draft as the day's entry and returns it
+func (h *Handlers) PublishDraft(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	journalID := chi.URLParam(r, "journalId")
+	entryDate := chi.URLParam(r, "date")
+
+	// The body is optional
+	var req PublishDraftRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	entry, err := h.service.PublishDraft(r.Context(), userSub, journalID, entryDate, req.Force)
+	if err != nil {
+		writeDraftError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entry)
+}
@@ -0,0 +1,76 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// Change stream handlers
+
+// StreamEvents streams the user's journal events as Server-Sent Events. Each
+// event's id is its position in the event log; reconnecting clients send it
+// back as Last-Event-ID (or ?last_event_id=) to resume.
+func (h *Handlers) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+
+	started := false
+	err := h.service.StreamEvents(r.Context(), userSub, lastEventID, func(batch []store.LoggedEvent) error {
+		if !started {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("X-Accel-Buffering", "no")
+			w.WriteHeader(http.StatusOK)
+			// Ask clients to wait a few seconds before reconnecting
+			if _, err := fmt.Fprint(w, "retry: 5000\n\n"); err != nil {
+				return err
+			}
+			started = true
+		}
+
+		if len(batch) == 0 {
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return err
+			}
+		}
+		for _, event := range batch {
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.EventType, event.Payload); err != nil {
+				return err
+			}
+		}
+		flusher.Flush()
+		return nil
+	})
+	if err != nil && !started {
+		switch {
+		case strings.Contains(err.Error(), "invalid"):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case strings.Contains(err.Error(), "not enabled"):
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// Calendar feed handlers
+
+type CalendarFeedResponse struct {
+	ID             string `json:"id"`
+	JournalID      string `json:"journal_id"`
+	LastAccessedAt string `json:"last_accessed_at,omitempty"`
+	RevokedAt      string `json:"revoked_at,omitempty"`
+	CreatedAt      string `json:"created_at"`
+	Token          string `json:"token,omitempty"` // only returned on creation
+	Path           string `json:"path,omitempty"`
+}
+
+func calendarFeedResponse(feed store.CalendarFeed) CalendarFeedResponse {
+	response := CalendarFeedResponse{
+		ID:        feed.ID,
+		JournalID: feed.JournalID,
+		CreatedAt: feed.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if feed.LastAccessedAt.Valid {
+		response.LastAccessedAt = feed.LastAccessedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if feed.RevokedAt.Valid {
+		response.RevokedAt = feed.RevokedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return response
+}
+
+func (h *Handlers) CreateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	feed, token, err := h.service.CreateCalendarFeed(r.Context(), userSub, chi.URLParam(r, "id"))
+	if err != nil {
+		writeShareError(w, err)
+		return
+	}
+
+	response := calendarFeedResponse(feed)
+	response.Token = token
+	response.Path = "/feeds/" + token + ".ics"
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handlers) ListCalendarFeeds(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	feeds, err := h.service.ListCalendarFeeds(r.Context(), userSub, chi.URLParam(r, "id"))
+	if err != nil {
+		writeShareError(w, err)
+		return
+	}
+
+	response := make([]CalendarFeedResponse, len(feeds))
+	for i, feed := range feeds {
+		response[i] = calendarFeedResponse(feed)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handlers) RevokeCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.RevokeCalendarFeed(r.Context(), userSub, chi.URLParam(r, "id"), chi.URLParam(r, "feedId")); err != nil {
+		writeShareError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCalendarFeed serves a journal as iCalendar. It is public: the token in
+// the path is the credential, so calendar apps can subscribe to the URL.
+func (h *Handlers) GetCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	// Calendar apps often expect the URL to end in .ics
+	token := strings.TrimSuffix(chi.URLParam(r, "token"), ".ics")
+
+	calendar, err := h.service.CalendarFeed(r.Context(), token, r.Header.Get("If-None-Match"))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.NotFound(w, r)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("X-Robots-Tag", "noindex")
+	if calendar.ETag != "" {
+		w.Header().Set("ETag", calendar.ETag)
+	}
+	if calendar.NotModified {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	// ServeContent answers If-Modified-Since and sets Last-Modified
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	http.ServeContent(w, r, "", calendar.LastModified, bytes.NewReader(calendar.Body))
+}
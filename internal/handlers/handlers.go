@@ -7,11 +7,15 @@ package handlers
 
 import (
 	"encoding/json"
+	"io"
+	"log"
 	"net/http"
 	"strings"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/telluriancorp/ll-journal/internal/auth"
 	"github.com/telluriancorp/ll-journal/internal/journal"
+	"github.com/telluriancorp/ll-journal/internal/store"
 )
 
 type Handlers struct {
@@ -22,16 +26,18 @@ func New(service *journal.Service) *Handlers {
 	return &Handlers{service: service}
 }
 
-// getUserSub extracts user sub from request header (set by LL-proxy)
+// getUserSub returns the user sub verified by the auth middleware
 func getUserSub(r *http.Request) string {
-	return r.Header.Get("X-User-Sub")
+	return auth.Subject(r.Context())
 }
 
 // Journal handlers
 
 type CreateJournalRequest struct {
-	Title       string `json:"title"`
-	Description string `json:"description,omitempty"`
+	Title       string              `json:"title"`
+	Description string              `json:"description,omitempty"`
+	E2EE        bool                `json:"e2ee,omitempty"`
+	KeyEnvelope *KeyEnvelopeRequest `json:"key_envelope,omitempty"`
 }
 
 type UpdateJournalRequest struct {
@@ -57,7 +63,21 @@ func (h *Handlers) CreateJournal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	journal, err := h.service.CreateJournal(r.Context(), userSub, req.Title, req.Description)
+	var envelope *store.JournalKeyEnvelope
+	if req.E2EE {
+		if req.KeyEnvelope == nil {
+			http.Error(w, "key_envelope is required for e2ee journals", http.StatusBadRequest)
+			return
+		}
+		parsed, err := req.KeyEnvelope.toStore()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		envelope = &parsed
+	}
+
+	journal, err := h.service.CreateJournal(r.Context(), userSub, req.Title, req.Description, envelope)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -77,6 +97,10 @@ func (h *Handlers) GetJournal(w http.ResponseWriter, r *http.Request) {
 	journalID := chi.URLParam(r, "id")
 	journal, err := h.service.GetJournal(r.Context(), journalID, userSub)
 	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
 			http.Error(w, "Journal not found", http.StatusNotFound)
 			return
@@ -123,6 +147,10 @@ func (h *Handlers) UpdateJournal(w http.ResponseWriter, r *http.Request) {
 	// Get existing journal to preserve fields
 	existing, err := h.service.GetJournal(r.Context(), journalID, userSub)
 	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
 			http.Error(w, "Journal not found", http.StatusNotFound)
 			return
@@ -139,6 +167,10 @@ func (h *Handlers) UpdateJournal(w http.ResponseWriter, r *http.Request) {
 	description := req.Description
 
 	if err := h.service.UpdateJournal(r.Context(), journalID, userSub, title, description); err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -163,6 +195,10 @@ func (h *Handlers) DeleteJournal(w http.ResponseWriter, r *http.Request) {
 
 	journalID := chi.URLParam(r, "id")
 	if err := h.service.DeleteJournal(r.Context(), journalID, userSub); err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
 			http.Error(w, "Journal not found", http.StatusNotFound)
 			return
@@ -177,12 +213,15 @@ func (h *Handlers) DeleteJournal(w http.ResponseWriter, r *http.Request) {
 // Entry handlers
 
 type CreateEntryRequest struct {
-	EntryDate string `json:"entry_date"` // Format: YYYY-MM-DD
-	Content   string `json:"content"`
+	EntryDate      string          `json:"entry_date"` // Format: YYYY-MM-DD
+	Content        string          `json:"content"`
+	TemplateID     string          `json:"template_id,omitempty"`     // used when content is empty
+	ClientMetadata json.RawMessage `json:"client_metadata,omitempty"` // e2ee journals only
 }
 
 type UpdateEntryRequest struct {
-	Content string `json:"content"`
+	Content        string          `json:"content"`
+	ClientMetadata json.RawMessage `json:"client_metadata,omitempty"`
 }
 
 func (h *Handlers) CreateEntry(w http.ResponseWriter, r *http.Request) {
@@ -204,17 +243,28 @@ func (h *Handlers) CreateEntry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.Content == "" {
-		http.Error(w, "content is required", http.StatusBadRequest)
+	// entry_date may be "today" or "yesterday" in the user's time zone
+	entryDate, err := h.service.ResolveEntryDate(r.Context(), userSub, req.EntryDate)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	entry, err := h.service.CreateEntry(r.Context(), userSub, journalID, req.EntryDate, req.Content)
+	// Without content the service falls back to the template or the journal's default
+	entry, err := h.service.CreateEntry(r.Context(), userSub, journalID, entryDate, req.Content, req.TemplateID, string(req.ClientMetadata))
 	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		if strings.Contains(err.Error(), "already exists") {
 			http.Error(w, err.Error(), http.StatusConflict)
 			return
 		}
+		if strings.Contains(err.Error(), "invalid") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
 			http.Error(w, err.Error(), http.StatusNotFound)
 			return
@@ -240,6 +290,10 @@ func (h *Handlers) GetEntry(w http.ResponseWriter, r *http.Request) {
 
 	entry, content, err := h.service.GetEntry(r.Context(), userSub, journalID, entryDate)
 	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
 			http.Error(w, "Entry not found", http.StatusNotFound)
 			return
@@ -252,9 +306,49 @@ func (h *Handlers) GetEntry(w http.ResponseWriter, r *http.Request) {
 		"entry":   entry,
 		"content": string(content),
 	}
+	if local, ok := entry.CreatedAtLocal(); ok {
+		response["created_at_local"] = local.Format("2006-01-02T15:04:05Z07:00")
+	}
+	h.writeContent(w, r, journalID, content, response)
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+// GetEntryContent streams the raw entry content instead of wrapping it in JSON
+func (h *Handlers) GetEntryContent(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	journalID := chi.URLParam(r, "journalId")
+	entryDate := chi.URLParam(r, "date")
+
+	journal, entry, body, err := h.service.OpenEntryContent(r.Context(), userSub, journalID, entryDate)
+	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, "Entry not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer body.Close()
+
+	contentType := "text/markdown; charset=utf-8"
+	if journal.E2EE {
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", contentType)
+	if entry.GitCommitHash.Valid {
+		w.Header().Set("ETag", `"`+entry.GitCommitHash.String+`"`)
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		log.Printf("Warning: failed to stream entry content: %v", err)
+	}
 }
 
 func (h *Handlers) ListEntries(w http.ResponseWriter, r *http.Request) {
@@ -267,6 +361,10 @@ func (h *Handlers) ListEntries(w http.ResponseWriter, r *http.Request) {
 	journalID := chi.URLParam(r, "journalId")
 	entries, err := h.service.ListEntries(r.Context(), userSub, journalID)
 	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
 			http.Error(w, "Journal not found", http.StatusNotFound)
 			return
@@ -300,8 +398,16 @@ func (h *Handlers) UpdateEntry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	entry, err := h.service.UpdateEntry(r.Context(), userSub, journalID, entryDate, req.Content)
+	entry, err := h.service.UpdateEntry(r.Context(), userSub, journalID, entryDate, req.Content, string(req.ClientMetadata))
 	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if strings.Contains(err.Error(), "invalid") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
 			http.Error(w, err.Error(), http.StatusNotFound)
 			return
@@ -325,6 +431,10 @@ func (h *Handlers) DeleteEntry(w http.ResponseWriter, r *http.Request) {
 	entryDate := chi.URLParam(r, "date")
 
 	if err := h.service.DeleteEntry(r.Context(), userSub, journalID, entryDate); err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
 			http.Error(w, "Entry not found", http.StatusNotFound)
 			return
@@ -350,6 +460,10 @@ func (h *Handlers) ListVersions(w http.ResponseWriter, r *http.Request) {
 
 	versions, err := h.service.ListVersions(r.Context(), userSub, journalID, entryDate)
 	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
 			http.Error(w, "Journal or entry not found", http.StatusNotFound)
 			return
@@ -395,6 +509,10 @@ func (h *Handlers) GetVersion(w http.ResponseWriter, r *http.Request) {
 
 	content, err := h.service.GetVersion(r.Context(), userSub, journalID, entryDate, commitHash)
 	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
 			http.Error(w, "Version not found", http.StatusNotFound)
 			return
@@ -403,11 +521,52 @@ func (h *Handlers) GetVersion(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := map[string]interface{}{
+	h.writeContent(w, r, journalID, content, map[string]interface{}{
 		"commit_hash": commitHash,
 		"content":     string(content),
+	})
+}
+
+// writeContent negotiates between JSON, raw Markdown and rendered HTML.
+// JSON responses include the HTML too when ?render=html is given.
+func (h *Handlers) writeContent(w http.ResponseWriter, r *http.Request, journalID string, content []byte, response map[string]interface{}) {
+	w.Header().Add("Vary", "Accept")
+
+	switch negotiate(r, mediaJSON, mediaMarkdown, mediaHTML) {
+	case mediaMarkdown:
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		w.Write(content)
+
+	case mediaHTML:
+		html, err := h.service.RenderContent(r.Context(), getUserSub(r), journalID, content)
+		if err != nil {
+			writeRenderError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(html)
+
+	case mediaJSON:
+		if r.URL.Query().Get("render") == "html" {
+			html, err := h.service.RenderContent(r.Context(), getUserSub(r), journalID, content)
+			if err != nil {
+				writeRenderError(w, err)
+				return
+			}
+			response["html"] = string(html)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+
+	default:
+		http.Error(w, "Not Acceptable: supported types are application/json, text/markdown and text/html", http.StatusNotAcceptable)
 	}
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+func writeRenderError(w http.ResponseWriter, err error) {
+	if strings.Contains(err.Error(), "not acceptable") {
+		http.Error(w, err.Error(), http.StatusNotAcceptable)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
 }
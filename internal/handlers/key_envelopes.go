@@ -0,0 +1,129 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// Key envelope handlers (end-to-end encrypted journals)
+
+// KeyEnvelopeRequest carries a journal key wrapped by the client. The server
+// stores it verbatim and never sees the unwrapped key.
+type KeyEnvelopeRequest struct {
+	Algorithm  string          `json:"algorithm"`
+	KeyID      string          `json:"key_id,omitempty"`
+	WrappedKey string          `json:"wrapped_key"`
+	Params     json.RawMessage `json:"params,omitempty"` // e.g. KDF salt and iterations
+}
+
+type KeyEnvelopeResponse struct {
+	JournalID  string          `json:"journal_id"`
+	Algorithm  string          `json:"algorithm"`
+	KeyID      string          `json:"key_id,omitempty"`
+	WrappedKey string          `json:"wrapped_key"`
+	Params     json.RawMessage `json:"params,omitempty"`
+	CreatedAt  string          `json:"created_at"`
+	UpdatedAt  string          `json:"updated_at"`
+}
+
+func (req KeyEnvelopeRequest) toStore() (store.JournalKeyEnvelope, error) {
+	if req.Algorithm == "" || req.WrappedKey == "" {
+		return store.JournalKeyEnvelope{}, errors.New("key_envelope requires algorithm and wrapped_key")
+	}
+	return store.JournalKeyEnvelope{
+		Algorithm:  req.Algorithm,
+		KeyID:      sql.NullString{String: req.KeyID, Valid: req.KeyID != ""},
+		WrappedKey: req.WrappedKey,
+		Params:     sql.NullString{String: string(req.Params), Valid: len(req.Params) > 0},
+	}, nil
+}
+
+func keyEnvelopeResponse(envelope store.JournalKeyEnvelope) KeyEnvelopeResponse {
+	response := KeyEnvelopeResponse{
+		JournalID:  envelope.JournalID,
+		Algorithm:  envelope.Algorithm,
+		KeyID:      envelope.KeyID.String,
+		WrappedKey: envelope.WrappedKey,
+		CreatedAt:  envelope.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:  envelope.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if envelope.Params.Valid {
+		response.Params = json.RawMessage(envelope.Params.String)
+	}
+	return response
+}
+
+func (h *Handlers) GetKeyEnvelope(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	journalID := chi.URLParam(r, "id")
+	envelope, err := h.service.GetKeyEnvelope(r.Context(), journalID, userSub)
+	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(keyEnvelopeResponse(envelope))
+}
+
+func (h *Handlers) UpdateKeyEnvelope(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	journalID := chi.URLParam(r, "id")
+	var req KeyEnvelopeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	envelope, err := req.toStore()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.service.UpdateKeyEnvelope(r.Context(), journalID, userSub, envelope)
+	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(keyEnvelopeResponse(updated))
+}
@@ -0,0 +1,117 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Link handlers
+
+type BacklinkResponse struct {
+	JournalID string `json:"journal_id"`
+	EntryID   string `json:"entry_id"`
+	EntryDate string `json:"entry_date"`
+	CreatedAt string `json:"created_at"`
+}
+
+type GraphNodeResponse struct {
+	ID        string `json:"id"` // <journal id>/<date>
+	JournalID string `json:"journal_id"`
+	Date      string `json:"date"`
+	EntryID   string `json:"entry_id,omitempty"`
+}
+
+type GraphEdgeResponse struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+type LinkGraphResponse struct {
+	Nodes []GraphNodeResponse `json:"nodes"`
+	Edges []GraphEdgeResponse `json:"edges"`
+}
+
+// writeLinkError maps link errors to status codes
+func writeLinkError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "forbidden"):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case strings.Contains(err.Error(), "invalid"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case strings.Contains(err.Error(), "not found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handlers) ListBacklinks(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	journalID := chi.URLParam(r, "journalId")
+	entryDate := chi.URLParam(r, "date")
+
+	links, err := h.service.Backlinks(r.Context(), userSub, journalID, entryDate)
+	if err != nil {
+		writeLinkError(w, err)
+		return
+	}
+
+	response := make([]BacklinkResponse, len(links))
+	for i, link := range links {
+		response[i] = BacklinkResponse{
+			JournalID: link.SourceJournalID,
+			EntryID:   link.SourceEntryID,
+			EntryDate: link.SourceDate.Format("2006-01-02"),
+			CreatedAt: link.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handlers) GetLinkGraph(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	graph, err := h.service.LinkGraph(r.Context(), userSub, chi.URLParam(r, "id"))
+	if err != nil {
+		writeLinkError(w, err)
+		return
+	}
+
+	response := LinkGraphResponse{
+		Nodes: make([]GraphNodeResponse, len(graph.Nodes)),
+		Edges: make([]GraphEdgeResponse, len(graph.Edges)),
+	}
+	for i, node := range graph.Nodes {
+		response.Nodes[i] = GraphNodeResponse{
+			ID:        node.ID,
+			JournalID: node.JournalID,
+			Date:      node.Date,
+			EntryID:   node.EntryID,
+		}
+	}
+	for i, edge := range graph.Edges {
+		response.Edges[i] = GraphEdgeResponse{Source: edge.Source, Target: edge.Target}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,210 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// Member and invite handlers
+
+type MemberResponse struct {
+	UserSub   string `json:"user_sub"`
+	Role      string `json:"role"`
+	InvitedBy string `json:"invited_by,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+type InviteRequest struct {
+	UserSub string `json:"user_sub"`
+	Role    string `json:"role"`
+}
+
+type InviteResponse struct {
+	ID         string `json:"id"`
+	JournalID  string `json:"journal_id"`
+	InviteeSub string `json:"invitee_sub"`
+	Role       string `json:"role"`
+	InvitedBy  string `json:"invited_by"`
+	CreatedAt  string `json:"created_at"`
+}
+
+func inviteResponse(invite store.JournalInvite) InviteResponse {
+	return InviteResponse{
+		ID:         invite.ID,
+		JournalID:  invite.JournalID,
+		InviteeSub: invite.InviteeSub,
+		Role:       invite.Role,
+		InvitedBy:  invite.InvitedBy,
+		CreatedAt:  invite.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+func inviteResponses(invites []store.JournalInvite) []InviteResponse {
+	response := make([]InviteResponse, len(invites))
+	for i, invite := range invites {
+		response[i] = inviteResponse(invite)
+	}
+	return response
+}
+
+// writeMemberError maps membership errors to status codes
+func writeMemberError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "forbidden"):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case strings.Contains(err.Error(), "already exists"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "invalid"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case strings.Contains(err.Error(), "not found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handlers) ListMembers(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	members, err := h.service.ListMembers(r.Context(), chi.URLParam(r, "id"), userSub)
+	if err != nil {
+		writeMemberError(w, err)
+		return
+	}
+
+	response := make([]MemberResponse, len(members))
+	for i, m := range members {
+		response[i] = MemberResponse{
+			UserSub:   m.UserSub,
+			Role:      m.Role,
+			InvitedBy: m.InvitedBy.String,
+			CreatedAt: m.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handlers) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.RemoveMember(r.Context(), chi.URLParam(r, "id"), userSub, chi.URLParam(r, "userSub")); err != nil {
+		writeMemberError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handlers) InviteMember(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req InviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.UserSub == "" || req.Role == "" {
+		http.Error(w, "user_sub and role are required", http.StatusBadRequest)
+		return
+	}
+
+	invite, err := h.service.InviteMember(r.Context(), chi.URLParam(r, "id"), userSub, req.UserSub, req.Role)
+	if err != nil {
+		writeMemberError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(inviteResponse(invite))
+}
+
+func (h *Handlers) ListInvites(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	invites, err := h.service.ListInvites(r.Context(), chi.URLParam(r, "id"), userSub)
+	if err != nil {
+		writeMemberError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(inviteResponses(invites))
+}
+
+func (h *Handlers) RevokeInvite(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.RevokeInvite(r.Context(), chi.URLParam(r, "id"), userSub, chi.URLParam(r, "inviteId")); err != nil {
+		writeMemberError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListMyInvites lists the invites waiting for the current user
+func (h *Handlers) ListMyInvites(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	invites, err := h.service.ListPendingInvites(r.Context(), userSub)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(inviteResponses(invites))
+}
+
+func (h *Handlers) AcceptInvite(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	journal, err := h.service.AcceptInvite(r.Context(), chi.URLParam(r, "inviteId"), userSub)
+	if err != nil {
+		writeMemberError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(journal)
+}
@@ -0,0 +1,36 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Metrics reports cache counters in the Prometheus text format
+func (h *Handlers) Metrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	stats := h.service.CacheStats()
+	metrics := []struct {
+		name, help, kind string
+		value            func(i int) any
+	}{
+		{"ll_journal_cache_hits_total", "Cache lookups that found a value.", "counter", func(i int) any { return stats[i].Hits }},
+		{"ll_journal_cache_misses_total", "Cache lookups that found nothing.", "counter", func(i int) any { return stats[i].Misses }},
+		{"ll_journal_cache_evictions_total", "Values evicted to stay within the size limit.", "counter", func(i int) any { return stats[i].Evictions }},
+		{"ll_journal_cache_errors_total", "Cache operations that failed.", "counter", func(i int) any { return stats[i].Errors }},
+		{"ll_journal_cache_entries", "Values currently held.", "gauge", func(i int) any { return stats[i].Entries }},
+		{"ll_journal_cache_bytes", "Bytes currently held.", "gauge", func(i int) any { return stats[i].Bytes }},
+	}
+
+	for _, m := range metrics {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.kind)
+		for i, layer := range stats {
+			fmt.Fprintf(w, "%s{layer=%q} %v\n", m.name, layer.Layer, m.value(i))
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Media types entry and version endpoints can produce
+const (
+	mediaJSON     = "application/json"
+	mediaMarkdown = "text/markdown"
+	mediaHTML     = "text/html"
+)
+
+// negotiate picks the offer the client's Accept header prefers, honouring
+// q-values and wildcards. Each offer takes the q-value of the most specific
+// range that matches it, so "text/html;q=0, */*" excludes HTML. The first
+// offer wins ties and is the default when no Accept header is sent; ""
+// means nothing offered is acceptable.
+func negotiate(r *http.Request, offers ...string) string {
+	header := r.Header.Get("Accept")
+	if header == "" {
+		return offers[0]
+	}
+
+	type mediaRange struct {
+		mediaType string
+		q         float64
+	}
+	var ranges []mediaRange
+	for _, part := range strings.Split(header, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		ranges = append(ranges, mediaRange{mediaType: mediaType, q: q})
+	}
+
+	best, bestQ, bestSpecificity := "", 0.0, -1
+	for _, offer := range offers {
+		q, specificity := 0.0, -1
+		for _, rng := range ranges {
+			if s := matchMediaType(rng.mediaType, offer); s > specificity {
+				q, specificity = rng.q, s
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		// Later offers only win with a strictly better match
+		if q > bestQ || (q == bestQ && specificity > bestSpecificity) {
+			best, bestQ, bestSpecificity = offer, q, specificity
+		}
+	}
+	return best
+}
+
+// matchMediaType returns how specifically pattern matches offer: 2 for an
+// exact match, 1 for type/*, 0 for */* and -1 for no match
+func matchMediaType(pattern, offer string) int {
+	switch {
+	case pattern == offer:
+		return 2
+	case pattern == "*/*":
+		return 0
+	case strings.HasSuffix(pattern, "/*") && strings.HasPrefix(offer, strings.TrimSuffix(pattern, "*")):
+		return 1
+	default:
+		return -1
+	}
+}
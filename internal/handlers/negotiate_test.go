@@ -0,0 +1,44 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	offers := []string{mediaJSON, mediaMarkdown, mediaHTML}
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{name: "no header", accept: "", want: mediaJSON},
+		{name: "exact", accept: "text/html", want: mediaHTML},
+		{name: "wildcard takes first offer", accept: "*/*", want: mediaJSON},
+		{name: "type wildcard", accept: "text/*", want: mediaMarkdown},
+		{name: "q-values", accept: "text/html;q=0.5, text/markdown;q=0.9", want: mediaMarkdown},
+		{name: "specific beats wildcard at same q", accept: "*/*, text/html", want: mediaHTML},
+		{name: "browser", accept: "text/html,application/xhtml+xml,*/*;q=0.8", want: mediaHTML},
+		{name: "exclusion with wildcard", accept: "application/json;q=0, */*", want: mediaMarkdown},
+		{name: "exclusion before wildcard", accept: "text/html;q=0, text/markdown;q=0, */*;q=0.5", want: mediaJSON},
+		{name: "exclusion of type wildcard", accept: "text/*;q=0, */*", want: mediaJSON},
+		{name: "everything excluded", accept: "application/json;q=0, text/*;q=0", want: ""},
+		{name: "nothing offered", accept: "image/png", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			if got := negotiate(r, offers...); got != tt.want {
+				t.Errorf("negotiate(%q) = %q, want %q", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
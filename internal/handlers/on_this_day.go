@@ -0,0 +1,63 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// "On this day" handlers
+
+type MemoryResponse struct {
+	JournalID    string `json:"journal_id"`
+	JournalTitle string `json:"journal_title"`
+	EntryID      string `json:"entry_id"`
+	EntryDate    string `json:"entry_date"`
+	YearsAgo     int    `json:"years_ago"`
+	WordCount    *int32 `json:"word_count,omitempty"`
+	Excerpt      string `json:"excerpt,omitempty"`
+}
+
+// OnThisDay lists entries from the same day in earlier years. ?date= picks
+// the day; otherwise today is taken in the ?tz= time zone.
+func (h *Handlers) OnThisDay(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	memories, err := h.service.OnThisDay(r.Context(), userSub, query.Get("date"), query.Get("tz"))
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]MemoryResponse, len(memories))
+	for i, memory := range memories {
+		response[i] = MemoryResponse{
+			JournalID:    memory.Journal.ID,
+			JournalTitle: memory.Journal.Title,
+			EntryID:      memory.Entry.ID,
+			EntryDate:    memory.Entry.EntryDate.Format("2006-01-02"),
+			YearsAgo:     memory.YearsAgo,
+			Excerpt:      memory.Excerpt,
+		}
+		if memory.Entry.WordCount.Valid {
+			response[i].WordCount = &memory.Entry.WordCount.Int32
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,93 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// Settings handlers
+
+type SettingsRequest struct {
+	TimeZone string `json:"time_zone"`
+}
+
+type SettingsResponse struct {
+	TimeZone string `json:"time_zone"`
+}
+
+func settingsResponse(settings store.UserSettings) SettingsResponse {
+	return SettingsResponse{TimeZone: settings.TimeZone}
+}
+
+func (h *Handlers) GetSettings(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	settings, err := h.service.GetSettings(r.Context(), userSub)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settingsResponse(settings))
+}
+
+func (h *Handlers) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req SettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	settings, err := h.service.UpdateSettings(r.Context(), userSub, req.TimeZone)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settingsResponse(settings))
+}
+
+// ResolveEntryDate rewrites a {date} of "today" or "yesterday" into a date in
+// the user's time zone, so handlers below it only ever see YYYY-MM-DD
+func (h *Handlers) ResolveEntryDate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rctx := chi.RouteContext(r.Context())
+		for i, key := range rctx.URLParams.Keys {
+			if key != "date" {
+				continue
+			}
+			date, err := h.service.ResolveEntryDate(r.Context(), getUserSub(r), rctx.URLParams.Values[i])
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			rctx.URLParams.Values[i] = date
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,283 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"encoding/json"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/telluriancorp/ll-journal/internal/journal"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// Share link handlers
+
+type CreateShareLinkRequest struct {
+	CommitHash string     `json:"commit_hash,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	Password   string     `json:"password,omitempty"`
+}
+
+type ShareLinkResponse struct {
+	ID                string `json:"id"`
+	CommitHash        string `json:"commit_hash,omitempty"`
+	PasswordProtected bool   `json:"password_protected"`
+	CreatedBy         string `json:"created_by"`
+	ExpiresAt         string `json:"expires_at,omitempty"`
+	RevokedAt         string `json:"revoked_at,omitempty"`
+	CreatedAt         string `json:"created_at"`
+	Token             string `json:"token,omitempty"` // only returned on creation
+	Path              string `json:"path,omitempty"`
+}
+
+type ShareAccessResponse struct {
+	Outcome    string `json:"outcome"`
+	RemoteAddr string `json:"remote_addr,omitempty"`
+	UserAgent  string `json:"user_agent,omitempty"`
+	AccessedAt string `json:"accessed_at"`
+}
+
+func shareLinkResponse(link store.ShareLink) ShareLinkResponse {
+	response := ShareLinkResponse{
+		ID:                link.ID,
+		CommitHash:        link.CommitHash.String,
+		PasswordProtected: link.PasswordHash.Valid,
+		CreatedBy:         link.CreatedBy,
+		CreatedAt:         link.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if link.ExpiresAt.Valid {
+		response.ExpiresAt = link.ExpiresAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if link.RevokedAt.Valid {
+		response.RevokedAt = link.RevokedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return response
+}
+
+// writeShareError maps share link errors to status codes
+func writeShareError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "forbidden"):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case strings.Contains(err.Error(), "invalid"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case strings.Contains(err.Error(), "not found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handlers) CreateShareLink(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req CreateShareLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	link, token, err := h.service.CreateShareLink(r.Context(), userSub,
+		chi.URLParam(r, "journalId"), chi.URLParam(r, "date"), journal.ShareLinkRequest{
+			CommitHash: req.CommitHash,
+			ExpiresAt:  req.ExpiresAt,
+			Password:   req.Password,
+		})
+	if err != nil {
+		writeShareError(w, err)
+		return
+	}
+
+	response := shareLinkResponse(link)
+	response.Token = token
+	response.Path = "/share/" + token
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handlers) ListShareLinks(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	links, err := h.service.ListShareLinks(r.Context(), userSub, chi.URLParam(r, "journalId"), chi.URLParam(r, "date"))
+	if err != nil {
+		writeShareError(w, err)
+		return
+	}
+
+	response := make([]ShareLinkResponse, len(links))
+	for i, link := range links {
+		response[i] = shareLinkResponse(link)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handlers) RevokeShareLink(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.RevokeShareLink(r.Context(), userSub,
+		chi.URLParam(r, "journalId"), chi.URLParam(r, "date"), chi.URLParam(r, "shareId")); err != nil {
+		writeShareError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handlers) ListShareAccess(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	accessLog, err := h.service.ListShareAccess(r.Context(), userSub,
+		chi.URLParam(r, "journalId"), chi.URLParam(r, "date"), chi.URLParam(r, "shareId"))
+	if err != nil {
+		writeShareError(w, err)
+		return
+	}
+
+	response := make([]ShareAccessResponse, len(accessLog))
+	for i, access := range accessLog {
+		response[i] = ShareAccessResponse{
+			Outcome:    access.Outcome,
+			RemoteAddr: access.RemoteAddr.String,
+			UserAgent:  access.UserAgent.String,
+			AccessedAt: access.AccessedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// Public share page
+
+var sharePage = template.Must(template.New("share").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>body{max-width:42rem;margin:2rem auto;padding:0 1rem;font-family:system-ui,sans-serif;line-height:1.6}img{max-width:100%}table{border-collapse:collapse}th,td{border:1px solid #ccc;padding:.25rem .5rem}header{color:#666;margin-bottom:2rem}</style>
+</head>
+<body>
+{{if .PasswordForm}}
+<form method="post">
+<p>This entry is password protected.</p>
+{{if .Error}}<p><strong>{{.Error}}</strong></p>{{end}}
+<input type="password" name="password" autofocus required>
+<button type="submit">Open</button>
+</form>
+{{else}}
+<header>{{.Title}}</header>
+<article>{{.Body}}</article>
+{{end}}
+</body>
+</html>
+`))
+
+type sharePageData struct {
+	Title        string
+	Body         template.HTML
+	PasswordForm bool
+	Error        string
+}
+
+// GetSharedEntry serves a share link without authentication. Passwords are
+// accepted from a POSTed form field or the X-Share-Password header.
+func (h *Handlers) GetSharedEntry(w http.ResponseWriter, r *http.Request) {
+	// The token is in the URL; keep it out of referrers, caches and indexes
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Robots-Tag", "noindex, nofollow")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; img-src 'self' https: data:; style-src 'unsafe-inline'; form-action 'self'")
+
+	password := r.Header.Get("X-Share-Password")
+	if r.Method == http.MethodPost {
+		password = r.PostFormValue("password")
+	}
+
+	shared, err := h.service.OpenShareLink(r.Context(), chi.URLParam(r, "token"), password, journal.ShareViewer{
+		RemoteAddr: r.RemoteAddr,
+		UserAgent:  r.UserAgent(),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "password required") {
+			data := sharePageData{Title: "Shared entry", PasswordForm: true}
+			if password != "" {
+				data.Error = "Incorrect password"
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.WriteHeader(http.StatusUnauthorized)
+			sharePage.Execute(w, data)
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, "Share link not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Vary", "Accept")
+	if negotiate(r, mediaHTML, mediaMarkdown) == mediaMarkdown {
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		w.Write(shared.Markdown)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := sharePage.Execute(w, sharePageData{
+		Title: shared.JournalTitle + " · " + shared.EntryDate.Format("January 2, 2006"),
+		// Already sanitized by the renderer
+		Body: template.HTML(shared.HTML),
+	}); err != nil {
+		log.Printf("Warning: failed to render shared entry: %v", err)
+	}
+}
+
+// GetSharedAttachment serves an attachment of a shared entry that is
+// encrypted at rest and so cannot be presigned
+func (h *Handlers) GetSharedAttachment(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Robots-Tag", "noindex, nofollow")
+
+	attachment, content, err := h.service.OpenSharedAttachment(r.Context(),
+		chi.URLParam(r, "token"), chi.URLParam(r, "attachmentId"), r.URL.Query().Get("sig"))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, "Attachment not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	serveAttachment(w, r, attachment, content)
+}
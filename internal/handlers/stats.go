@@ -0,0 +1,133 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// Statistics handlers
+
+type StatsResponse struct {
+	Entries       int64             `json:"entries"`
+	TotalWords    int64             `json:"total_words"`
+	AverageWords  float64           `json:"average_words"`
+	FirstEntry    string            `json:"first_entry,omitempty"`
+	LastEntry     string            `json:"last_entry,omitempty"`
+	CurrentStreak StreakResponse    `json:"current_streak"`
+	LongestStreak StreakResponse    `json:"longest_streak"`
+	Months        []MonthResponse   `json:"months"`
+	Weekdays      []WeekdayResponse `json:"weekdays"` // most active first
+	Heatmap       []DayResponse     `json:"heatmap"`  // the last year, days with entries only
+}
+
+type StreakResponse struct {
+	Days  int64  `json:"days"`
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
+type MonthResponse struct {
+	Month   string `json:"month"`
+	Entries int64  `json:"entries"`
+	Words   int64  `json:"words"`
+}
+
+type WeekdayResponse struct {
+	Weekday string `json:"weekday"`
+	Entries int64  `json:"entries"`
+	Words   int64  `json:"words"`
+}
+
+type DayResponse struct {
+	Date    string `json:"date"`
+	Entries int64  `json:"entries"`
+	Words   int64  `json:"words"`
+}
+
+func streakResponse(streak store.Streak) StreakResponse {
+	response := StreakResponse{Days: streak.Days}
+	if streak.Start.Valid {
+		response.Start = streak.Start.Time.Format("2006-01-02")
+		response.End = streak.End.Time.Format("2006-01-02")
+	}
+	return response
+}
+
+func statsResponse(stats store.EntryStats) StatsResponse {
+	response := StatsResponse{
+		Entries:       stats.Entries,
+		TotalWords:    stats.TotalWords,
+		AverageWords:  stats.AverageWords,
+		CurrentStreak: streakResponse(stats.CurrentStreak),
+		LongestStreak: streakResponse(stats.LongestStreak),
+		Months:        make([]MonthResponse, len(stats.Months)),
+		Weekdays:      make([]WeekdayResponse, len(stats.Weekdays)),
+		Heatmap:       make([]DayResponse, len(stats.Days)),
+	}
+	if stats.FirstEntry.Valid {
+		response.FirstEntry = stats.FirstEntry.Time.Format("2006-01-02")
+		response.LastEntry = stats.LastEntry.Time.Format("2006-01-02")
+	}
+	for i, month := range stats.Months {
+		response.Months[i] = MonthResponse{Month: month.Month, Entries: month.Entries, Words: month.Words}
+	}
+	for i, weekday := range stats.Weekdays {
+		response.Weekdays[i] = WeekdayResponse{Weekday: weekday.Weekday.String(), Entries: weekday.Entries, Words: weekday.Words}
+	}
+	for i, day := range stats.Days {
+		response.Heatmap[i] = DayResponse{Date: day.Date.Format("2006-01-02"), Entries: day.Entries, Words: day.Words}
+	}
+	return response
+}
+
+func (h *Handlers) GetJournalStats(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	stats, err := h.service.JournalStats(r.Context(), userSub, chi.URLParam(r, "id"))
+	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, "Journal not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(statsResponse(stats))
+}
+
+// GetUserStats returns statistics across all journals the user is a member of
+func (h *Handlers) GetUserStats(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	stats, err := h.service.UserStats(r.Context(), userSub)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(statsResponse(stats))
+}
@@ -0,0 +1,222 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/telluriancorp/ll-journal/internal/journal"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// Delta sync handlers
+
+type SyncJournalResponse struct {
+	ID          string `json:"id"`
+	OwnerSub    string `json:"owner_sub"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	E2EE        bool   `json:"e2ee"`
+	Role        string `json:"role"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+type SyncEntryResponse struct {
+	ID             string   `json:"id"`
+	JournalID      string   `json:"journal_id"`
+	EntryDate      string   `json:"entry_date"`
+	CommitHash     string   `json:"commit_hash"`
+	WordCount      int      `json:"word_count"`
+	Mood           string   `json:"mood,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	ClientMetadata string   `json:"client_metadata,omitempty"`
+	CreatedAt      string   `json:"created_at"`
+	UpdatedAt      string   `json:"updated_at"`
+	Content        *string  `json:"content,omitempty"`
+}
+
+type SyncChangeResponse struct {
+	Seq       int64                `json:"seq"`
+	Entity    string               `json:"entity"`
+	ID        string               `json:"id"`
+	JournalID string               `json:"journal_id"`
+	Change    string               `json:"change"`
+	Journal   *SyncJournalResponse `json:"journal,omitempty"`
+	Entry     *SyncEntryResponse   `json:"entry,omitempty"`
+}
+
+type SyncResponse struct {
+	Changes []SyncChangeResponse `json:"changes"`
+	Cursor  string               `json:"cursor"`
+	HasMore bool                 `json:"has_more"`
+}
+
+type SyncPushRequest struct {
+	Changes []SyncPushChange `json:"changes"`
+}
+
+type SyncPushChange struct {
+	ClientRef      string `json:"client_ref,omitempty"` // echoed back to match results
+	Op             string `json:"op"`                   // create, update or delete
+	JournalID      string `json:"journal_id"`
+	EntryDate      string `json:"entry_date"`
+	Content        string `json:"content,omitempty"`
+	ClientMetadata string `json:"client_metadata,omitempty"`
+	BaseCommitHash string `json:"base_commit_hash,omitempty"` // update and delete only
+}
+
+type SyncPushResult struct {
+	ClientRef string             `json:"client_ref,omitempty"`
+	Status    string             `json:"status"`
+	Error     string             `json:"error,omitempty"`
+	Entry     *SyncEntryResponse `json:"entry,omitempty"`
+}
+
+func syncJournalResponse(j store.Journal) *SyncJournalResponse {
+	return &SyncJournalResponse{
+		ID:          j.ID,
+		OwnerSub:    j.UserSub,
+		Title:       j.Title,
+		Description: j.Description.String,
+		E2EE:        j.E2EE,
+		Role:        j.Role,
+		CreatedAt:   j.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:   j.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+func syncEntryResponse(entry store.JournalEntry, content []byte) *SyncEntryResponse {
+	response := &SyncEntryResponse{
+		ID:             entry.ID,
+		JournalID:      entry.JournalID,
+		EntryDate:      entry.EntryDate.Format("2006-01-02"),
+		CommitHash:     entry.GitCommitHash.String,
+		WordCount:      int(entry.WordCount.Int32),
+		Mood:           entry.Mood.String,
+		Tags:           entry.Tags,
+		ClientMetadata: entry.ClientMetadata.String,
+		CreatedAt:      entry.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:      entry.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if content != nil {
+		text := string(content)
+		response.Content = &text
+	}
+	return response
+}
+
+// GetSyncChanges returns what changed since the ?since= cursor. Pass the
+// returned cursor next time; while has_more is set, call again right away.
+func (h *Handlers) GetSyncChanges(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	limit := 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		if limit, err = strconv.Atoi(limitStr); err != nil || limit <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+	includeContent := r.URL.Query().Get("include_content") == "true"
+
+	page, err := h.service.SyncChanges(r.Context(), userSub, r.URL.Query().Get("since"), limit, includeContent)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := SyncResponse{
+		Changes: make([]SyncChangeResponse, len(page.Changes)),
+		Cursor:  page.Cursor,
+		HasMore: page.HasMore,
+	}
+	for i, item := range page.Changes {
+		change := SyncChangeResponse{
+			Seq:       item.Seq,
+			Entity:    item.EntityType,
+			ID:        item.EntityID,
+			JournalID: item.JournalID,
+			Change:    item.Change,
+		}
+		if item.Journal != nil {
+			change.Journal = syncJournalResponse(*item.Journal)
+		}
+		if item.Entry != nil {
+			change.Entry = syncEntryResponse(*item.Entry, item.Content)
+		}
+		response.Changes[i] = change
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// PushSyncChanges applies a batch of offline entry changes and reports each
+// one as applied, conflict or error
+func (h *Handlers) PushSyncChanges(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req SyncPushRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	changes := make([]journal.SyncPush, len(req.Changes))
+	for i, change := range req.Changes {
+		changes[i] = journal.SyncPush{
+			ClientRef:      change.ClientRef,
+			Op:             change.Op,
+			JournalID:      change.JournalID,
+			EntryDate:      change.EntryDate,
+			Content:        change.Content,
+			ClientMetadata: change.ClientMetadata,
+			BaseCommitHash: change.BaseCommitHash,
+		}
+	}
+
+	results, err := h.service.PushChanges(r.Context(), userSub, changes)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]SyncPushResult, len(results))
+	for i, result := range results {
+		response[i] = SyncPushResult{
+			ClientRef: result.ClientRef,
+			Status:    result.Status,
+			Error:     result.Error,
+		}
+		if result.Entry != nil {
+			response[i].Entry = syncEntryResponse(*result.Entry, result.Content)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"results": response})
+}
@@ -0,0 +1,205 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/telluriancorp/ll-journal/internal/journal"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// Template handlers
+
+type TemplateRequest struct {
+	JournalID string   `json:"journal_id,omitempty"` // creation only; omit for a personal template
+	Name      string   `json:"name"`
+	Body      string   `json:"body"`
+	Prompts   []string `json:"prompts,omitempty"`
+}
+
+type TemplateResponse struct {
+	ID        string   `json:"id"`
+	UserSub   string   `json:"user_sub"`
+	JournalID string   `json:"journal_id,omitempty"`
+	Name      string   `json:"name"`
+	Body      string   `json:"body"`
+	Prompts   []string `json:"prompts"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+type DefaultTemplateRequest struct {
+	TemplateID string `json:"template_id"` // empty or null clears the default
+}
+
+func templateResponse(template store.EntryTemplate) TemplateResponse {
+	prompts := template.Prompts
+	if prompts == nil {
+		prompts = []string{}
+	}
+	return TemplateResponse{
+		ID:        template.ID,
+		UserSub:   template.UserSub,
+		JournalID: template.JournalID.String,
+		Name:      template.Name,
+		Body:      template.Body,
+		Prompts:   prompts,
+		CreatedAt: template.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: template.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+func (req TemplateRequest) toService() journal.TemplateRequest {
+	return journal.TemplateRequest{
+		JournalID: req.JournalID,
+		Name:      req.Name,
+		Body:      req.Body,
+		Prompts:   req.Prompts,
+	}
+}
+
+// writeTemplateError maps template errors to status codes
+func writeTemplateError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "forbidden"):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case strings.Contains(err.Error(), "invalid"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case strings.Contains(err.Error(), "not found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handlers) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req TemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	template, err := h.service.CreateTemplate(r.Context(), userSub, req.toService())
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(templateResponse(template))
+}
+
+// ListTemplates lists personal templates, or a journal's with ?journal_id=
+func (h *Handlers) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	templates, err := h.service.ListTemplates(r.Context(), userSub, r.URL.Query().Get("journal_id"))
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	response := make([]TemplateResponse, len(templates))
+	for i, template := range templates {
+		response[i] = templateResponse(template)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handlers) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	template, err := h.service.GetTemplate(r.Context(), userSub, chi.URLParam(r, "templateId"))
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(templateResponse(template))
+}
+
+func (h *Handlers) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req TemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	template, err := h.service.UpdateTemplate(r.Context(), userSub, chi.URLParam(r, "templateId"), req.toService())
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(templateResponse(template))
+}
+
+func (h *Handlers) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.DeleteTemplate(r.Context(), userSub, chi.URLParam(r, "templateId")); err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handlers) SetDefaultTemplate(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req DefaultTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	journal, err := h.service.SetDefaultTemplate(r.Context(), userSub, chi.URLParam(r, "id"), req.TemplateID)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(journal)
+}
@@ -0,0 +1,183 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/telluriancorp/ll-journal/internal/journal"
+)
+
+// Direct upload handlers
+
+type RequestUploadRequest struct {
+	Kind        string `json:"kind"` // "entry" or "attachment"
+	ContentType string `json:"content_type"`
+	SizeBytes   int64  `json:"size_bytes"`
+	Filename    string `json:"filename,omitempty"` // attachments only
+}
+
+type UploadResponse struct {
+	UploadID  string            `json:"upload_id"`
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt string            `json:"expires_at"` // when the URL stops working
+	ConfirmBy string            `json:"confirm_by"` // when the upload is cleaned up unless confirmed
+}
+
+type ConfirmUploadRequest struct {
+	ClientMetadata json.RawMessage `json:"client_metadata,omitempty"` // e2ee entries only
+}
+
+func (h *Handlers) RequestUpload(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	journalID := chi.URLParam(r, "journalId")
+	entryDate := chi.URLParam(r, "date")
+
+	var req RequestUploadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	upload, presigned, err := h.service.RequestUpload(r.Context(), userSub, journalID, entryDate, journal.UploadRequest{
+		Kind:        req.Kind,
+		ContentType: req.ContentType,
+		Filename:    req.Filename,
+		SizeBytes:   req.SizeBytes,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if strings.Contains(err.Error(), "invalid") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(UploadResponse{
+		UploadID:  upload.ID,
+		Method:    presigned.Method,
+		URL:       presigned.URL,
+		Headers:   presigned.Headers,
+		ExpiresAt: presigned.Expires.Format("2006-01-02T15:04:05Z07:00"),
+		ConfirmBy: upload.ExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+	})
+}
+
+func (h *Handlers) ConfirmUpload(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	journalID := chi.URLParam(r, "journalId")
+	entryDate := chi.URLParam(r, "date")
+	uploadID := chi.URLParam(r, "uploadId")
+
+	// The body is optional
+	var req ConfirmUploadRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	result, err := h.service.ConfirmUpload(r.Context(), userSub, journalID, entryDate, uploadID, string(req.ClientMetadata))
+	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if strings.Contains(err.Error(), "already confirmed") {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if strings.Contains(err.Error(), "invalid") {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var response interface{}
+	if result.Attachment != nil {
+		response, err = h.attachmentResponse(r, *result.Attachment)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	} else {
+		response = result.Entry
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handlers) GetEntryDownloadURL(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	journalID := chi.URLParam(r, "journalId")
+	entryDate := chi.URLParam(r, "date")
+
+	url, err := h.service.EntryDownloadURL(r.Context(), userSub, journalID, entryDate)
+	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, "Entry not found", http.StatusNotFound)
+			return
+		}
+		if strings.Contains(err.Error(), "unavailable") {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// ?redirect=1 sends the client straight to the presigned URL
+	if r.URL.Query().Get("redirect") != "" {
+		http.Redirect(w, r, url, http.StatusFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"url": url})
+}
@@ -0,0 +1,257 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/telluriancorp/ll-journal/internal/journal"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// Webhook handlers
+
+type WebhookRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types,omitempty"` // omit for all events
+	Active     *bool    `json:"active,omitempty"`      // update only
+	System     bool     `json:"system,omitempty"`      // creation only; admins
+}
+
+type WebhookResponse struct {
+	ID         string   `json:"id"`
+	System     bool     `json:"system"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Active     bool     `json:"active"`
+	CreatedBy  string   `json:"created_by"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+	Secret     string   `json:"secret,omitempty"` // only returned on creation
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string `json:"id"`
+	EventID        string `json:"event_id"`
+	EventType      string `json:"event_type"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	NextAttemptAt  string `json:"next_attempt_at,omitempty"`
+	LastStatusCode int    `json:"last_status_code,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	DeliveredAt    string `json:"delivered_at,omitempty"`
+	CreatedAt      string `json:"created_at"`
+}
+
+func webhookResponse(sub store.WebhookSubscription) WebhookResponse {
+	eventTypes := sub.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	return WebhookResponse{
+		ID:         sub.ID,
+		System:     !sub.UserSub.Valid,
+		URL:        sub.URL,
+		EventTypes: eventTypes,
+		Active:     sub.Active,
+		CreatedBy:  sub.CreatedBy,
+		CreatedAt:  sub.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:  sub.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+func webhookDeliveryResponse(delivery store.WebhookDelivery) WebhookDeliveryResponse {
+	response := WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: int(delivery.LastStatusCode.Int32),
+		LastError:      delivery.LastError.String,
+		CreatedAt:      delivery.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if delivery.Status == store.DeliveryPending {
+		response.NextAttemptAt = delivery.NextAttemptAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if delivery.DeliveredAt.Valid {
+		response.DeliveredAt = delivery.DeliveredAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return response
+}
+
+func (req WebhookRequest) toService() journal.WebhookRequest {
+	return journal.WebhookRequest{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Active:     req.Active,
+		System:     req.System,
+	}
+}
+
+// writeWebhookError maps webhook errors to status codes
+func writeWebhookError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "forbidden"):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case strings.Contains(err.Error(), "invalid"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case strings.Contains(err.Error(), "not found"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "not enabled"):
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handlers) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	sub, err := h.service.CreateWebhook(r.Context(), userSub, req.toService())
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	response := webhookResponse(sub)
+	response.Secret = sub.Secret
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handlers) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	subs, err := h.service.ListWebhooks(r.Context(), userSub)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	response := make([]WebhookResponse, len(subs))
+	for i, sub := range subs {
+		response[i] = webhookResponse(sub)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handlers) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	sub, err := h.service.GetWebhook(r.Context(), userSub, chi.URLParam(r, "webhookId"))
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(webhookResponse(sub))
+}
+
+func (h *Handlers) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	sub, err := h.service.UpdateWebhook(r.Context(), userSub, chi.URLParam(r, "webhookId"), req.toService())
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(webhookResponse(sub))
+}
+
+func (h *Handlers) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.DeleteWebhook(r.Context(), userSub, chi.URLParam(r, "webhookId")); err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handlers) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	deliveries, err := h.service.ListWebhookDeliveries(r.Context(), userSub, chi.URLParam(r, "webhookId"))
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	response := make([]WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		response[i] = webhookDeliveryResponse(delivery)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handlers) ReplayWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	userSub := getUserSub(r)
+	if userSub == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	delivery, err := h.service.ReplayWebhookDelivery(r.Context(), userSub,
+		chi.URLParam(r, "webhookId"), chi.URLParam(r, "deliveryId"))
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(webhookDeliveryResponse(delivery))
+}
@@ -0,0 +1,105 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+// Package ical writes iCalendar (RFC 5545) documents of all-day events
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the longest content line allowed before folding
+const maxLineOctets = 75
+
+type Calendar struct {
+	ProductID   string // e.g. -//Tellurian Corp//LL-Journal//EN
+	Name        string
+	Description string
+	Events      []Event
+}
+
+// Event is an all-day event on Date
+type Event struct {
+	UID         string
+	Date        time.Time
+	Summary     string
+	Description string
+	Created     time.Time
+	Modified    time.Time
+}
+
+// Marshal encodes the calendar with CRLF line endings and folded lines
+func (c Calendar) Marshal() []byte {
+	var w writer
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", c.ProductID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		w.line("X-WR-CALNAME", escape(c.Name))
+	}
+	if c.Description != "" {
+		w.line("X-WR-CALDESC", escape(c.Description))
+	}
+
+	for _, event := range c.Events {
+		w.line("BEGIN", "VEVENT")
+		w.line("UID", event.UID)
+		w.line("DTSTAMP", utc(event.Modified))
+		w.line("CREATED", utc(event.Created))
+		w.line("LAST-MODIFIED", utc(event.Modified))
+		w.line("DTSTART;VALUE=DATE", event.Date.Format("20060102"))
+		w.line("DTEND;VALUE=DATE", event.Date.AddDate(0, 0, 1).Format("20060102"))
+		w.line("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			w.line("DESCRIPTION", escape(event.Description))
+		}
+		w.line("TRANSP", "TRANSPARENT")
+		w.line("END", "VEVENT")
+	}
+
+	w.line("END", "VCALENDAR")
+	return w.buf.Bytes()
+}
+
+type writer struct {
+	buf bytes.Buffer
+}
+
+// line writes "NAME:value", folding it into continuation lines that start
+// with a space so that no line exceeds 75 octets. Folds never split a
+// UTF-8 sequence.
+func (w *writer) line(name, value string) {
+	line := name + ":" + value
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.buf.WriteString(line[:cut])
+		w.buf.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space of a continuation line counts towards its length
+		limit = maxLineOctets - 1
+	}
+	w.buf.WriteString(line)
+	w.buf.WriteString("\r\n")
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escape escapes a TEXT value
+func escape(text string) string {
+	return escaper.Replace(text)
+}
+
+func utc(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
@@ -0,0 +1,114 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "plain", text: "Morning walk", want: "Morning walk"},
+		{name: "separators", text: "eggs, toast; tea", want: `eggs\, toast\; tea`},
+		{name: "backslash", text: `C:\notes`, want: `C:\\notes`},
+		{name: "newlines", text: "one\ntwo\r\nthree\rfour", want: `one\ntwo\nthree\nfour`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escape(tt.text); got != tt.want {
+				t.Errorf("escape(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineFolding(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		lines int
+	}{
+		{name: "short", value: "Tuesday", lines: 1},
+		{name: "exactly 75 octets", value: strings.Repeat("a", 75-len("SUMMARY:")), lines: 1},
+		{name: "76 octets", value: strings.Repeat("a", 76-len("SUMMARY:")), lines: 2},
+		{name: "long", value: strings.Repeat("a", 300), lines: 5},
+		{name: "multibyte", value: strings.Repeat("é", 100), lines: 3},
+		{name: "four-byte runes", value: strings.Repeat("😀", 40), lines: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var w writer
+			w.line("SUMMARY", tt.value)
+			out := w.buf.String()
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("line %q does not end in CRLF", out)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			if len(lines) != tt.lines {
+				t.Errorf("got %d lines, want %d: %q", len(lines), tt.lines, lines)
+			}
+			var unfolded strings.Builder
+			for i, line := range lines {
+				if len(line) > maxLineOctets {
+					t.Errorf("line %d is %d octets, want at most %d", i, len(line), maxLineOctets)
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d splits a UTF-8 sequence: %q", i, line)
+				}
+				if i > 0 {
+					if !strings.HasPrefix(line, " ") {
+						t.Fatalf("continuation line %d does not start with a space: %q", i, line)
+					}
+					line = line[1:]
+				}
+				unfolded.WriteString(line)
+			}
+			if want := "SUMMARY:" + tt.value; unfolded.String() != want {
+				t.Errorf("unfolded = %q, want %q", unfolded.String(), want)
+			}
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	date := time.Date(2025, 12, 24, 0, 0, 0, 0, time.UTC)
+	out := string(Calendar{
+		ProductID: "-//Tellurian Corp//LL-Journal//EN",
+		Name:      "Travel, 2025",
+		Events: []Event{{
+			UID:      "e1@lifelogger.life",
+			Date:     date,
+			Summary:  "Lisbon; day one",
+			Created:  date,
+			Modified: date.Add(time.Hour),
+		}},
+	}.Marshal())
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Travel\\, 2025\r\n",
+		"DTSTAMP:20251224T010000Z\r\n",
+		"DTSTART;VALUE=DATE:20251224\r\n",
+		"DTEND;VALUE=DATE:20251225\r\n",
+		"SUMMARY:Lisbon\\; day one\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Marshal() is missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "DESCRIPTION") || strings.Contains(out, "X-WR-CALDESC") {
+		t.Errorf("Marshal() wrote empty optional properties:\n%s", out)
+	}
+}
@@ -0,0 +1,291 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/telluriancorp/ll-journal/internal/s3"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// DefaultAttachmentMaxBytes is the attachment size limit when none is configured
+const DefaultAttachmentMaxBytes = 25 << 20
+
+// sealedAttachmentType is the S3 content type of attachments encrypted at
+// rest; the real one is kept in Postgres
+const sealedAttachmentType = "application/octet-stream"
+
+// WithAttachmentMaxBytes limits the size of a single attachment
+func WithAttachmentMaxBytes(n int64) Option {
+	return func(s *Service) {
+		if n > 0 {
+			s.attachmentMaxBytes = n
+		}
+	}
+}
+
+// AttachmentMaxBytes returns the size limit of a single attachment
+func (s *Service) AttachmentMaxBytes() int64 {
+	return s.attachmentMaxBytes
+}
+
+// AddAttachment streams a file next to an entry in S3 and records it in
+// Postgres. With encryption at rest the file is sealed with the journal
+// owner's data key, which needs the whole file in memory.
+func (s *Service) AddAttachment(ctx context.Context, userSub, journalID, entryDate, filename, contentType string, body io.Reader) (store.Attachment, error) {
+	journal, entry, err := s.loadEntry(ctx, userSub, journalID, entryDate, RoleEditor)
+	if err != nil {
+		return store.Attachment{}, err
+	}
+
+	filename = cleanFilename(filename)
+	buffered := bufio.NewReader(s3.LimitReader(body, s.attachmentMaxBytes))
+	if contentType == "" || contentType == "application/octet-stream" {
+		head, _ := buffered.Peek(512)
+		contentType = http.DetectContentType(head)
+	}
+
+	token, err := randomToken(8)
+	if err != nil {
+		return store.Attachment{}, err
+	}
+	s3Key := s3.GenerateAttachmentKey(journal.UserSub, journalID, entryDate, token, filename)
+
+	sealed := s.sealsAttachments(journal)
+	var result s3.StreamResult
+	if sealed {
+		result, err = s.uploadSealedAttachment(ctx, journal.UserSub, s3Key, buffered)
+	} else {
+		result, err = s.s3.UploadStream(ctx, s3Key, buffered, contentType)
+	}
+	if errors.Is(err, s3.ErrTooLarge) {
+		return store.Attachment{}, fmt.Errorf("invalid attachment: larger than %d bytes", s.attachmentMaxBytes)
+	}
+	if err != nil {
+		return store.Attachment{}, fmt.Errorf("failed to upload to S3: %w", err)
+	}
+
+	attachment, err := s.store.CreateAttachment(ctx, store.Attachment{
+		EntryID:        entry.ID,
+		JournalID:      journalID,
+		S3Key:          s3Key,
+		Filename:       filename,
+		ContentType:    contentType,
+		SizeBytes:      result.Size,
+		ChecksumSHA256: result.SHA256,
+		Encrypted:      sealed,
+	})
+	if err != nil {
+		// Try to clean up S3 if database save fails
+		_ = s.s3.Delete(ctx, s3Key)
+		return store.Attachment{}, fmt.Errorf("failed to save attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// ListAttachments lists the attachments of an entry
+func (s *Service) ListAttachments(ctx context.Context, userSub, journalID, entryDate string) ([]store.Attachment, error) {
+	_, entry, err := s.loadEntry(ctx, userSub, journalID, entryDate, RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.ListEntryAttachments(ctx, entry.ID)
+}
+
+// GetAttachment gets a single attachment of an entry
+func (s *Service) GetAttachment(ctx context.Context, userSub, journalID, entryDate, attachmentID string) (store.Attachment, error) {
+	_, entry, err := s.loadEntry(ctx, userSub, journalID, entryDate, RoleViewer)
+	if err != nil {
+		return store.Attachment{}, err
+	}
+
+	attachment, err := s.store.GetAttachment(ctx, entry.ID, attachmentID)
+	if err != nil {
+		return store.Attachment{}, fmt.Errorf("attachment not found: %w", err)
+	}
+	return attachment, nil
+}
+
+// OpenAttachment returns an attachment of an entry with its content,
+// decrypted if it is sealed at rest
+func (s *Service) OpenAttachment(ctx context.Context, userSub, journalID, entryDate, attachmentID string) (store.Attachment, []byte, error) {
+	journal, entry, err := s.loadEntry(ctx, userSub, journalID, entryDate, RoleViewer)
+	if err != nil {
+		return store.Attachment{}, nil, err
+	}
+
+	attachment, err := s.store.GetAttachment(ctx, entry.ID, attachmentID)
+	if err != nil {
+		return store.Attachment{}, nil, fmt.Errorf("attachment not found: %w", err)
+	}
+	content, err := s.readAttachment(ctx, journal.UserSub, attachment)
+	if err != nil {
+		return store.Attachment{}, nil, err
+	}
+	return attachment, content, nil
+}
+
+// AttachmentURL returns a presigned download URL for an attachment. Sealed
+// attachments are ciphertext in S3, so they can only be downloaded through
+// OpenAttachment.
+func (s *Service) AttachmentURL(ctx context.Context, attachment store.Attachment) (string, error) {
+	if attachment.Encrypted {
+		return "", fmt.Errorf("direct download unavailable: attachment is encrypted at rest")
+	}
+	url, err := s.s3.PresignGet(ctx, attachment.S3Key, attachment.Filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to presign download: %w", err)
+	}
+	return url, nil
+}
+
+// DeleteAttachment removes an attachment from S3 and Postgres
+func (s *Service) DeleteAttachment(ctx context.Context, userSub, journalID, entryDate, attachmentID string) error {
+	_, entry, err := s.loadEntry(ctx, userSub, journalID, entryDate, RoleEditor)
+	if err != nil {
+		return err
+	}
+
+	attachment, err := s.store.GetAttachment(ctx, entry.ID, attachmentID)
+	if err != nil {
+		return fmt.Errorf("attachment not found: %w", err)
+	}
+
+	if err := s.s3.Delete(ctx, attachment.S3Key); err != nil {
+		// Log error but continue
+		fmt.Printf("Warning: failed to delete S3 object %s: %v\n", attachment.S3Key, err)
+	}
+
+	return s.store.DeleteAttachment(ctx, attachment.ID)
+}
+
+// sealsAttachments reports whether new attachments of a journal are encrypted
+// at rest. Clients of e2ee journals upload files they encrypted themselves.
+func (s *Service) sealsAttachments(journal store.Journal) bool {
+	return s.enc != nil && !journal.E2EE
+}
+
+// uploadSealedAttachment encrypts a file with the owner's data key and uploads
+// it. The size and checksum returned are those of the plaintext.
+func (s *Service) uploadSealedAttachment(ctx context.Context, ownerSub, s3Key string, body io.Reader) (s3.StreamResult, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return s3.StreamResult{}, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	blob, err := s.enc.Encrypt(ctx, ownerSub, data)
+	if err != nil {
+		return s3.StreamResult{}, fmt.Errorf("failed to encrypt attachment: %w", err)
+	}
+	if err := s.s3.UploadObject(ctx, s3Key, blob, sealedAttachmentType); err != nil {
+		return s3.StreamResult{}, err
+	}
+	sum := sha256.Sum256(data)
+	return s3.StreamResult{Size: int64(len(data)), SHA256: hex.EncodeToString(sum[:])}, nil
+}
+
+// readAttachment downloads an attachment, opening it if it is sealed
+func (s *Service) readAttachment(ctx context.Context, ownerSub string, attachment store.Attachment) ([]byte, error) {
+	blob, err := s.s3.Download(ctx, attachment.S3Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download from S3: %w", err)
+	}
+	if !attachment.Encrypted {
+		return blob, nil
+	}
+	content, err := s.open(ctx, ownerSub, blob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt attachment: %w", err)
+	}
+	return content, nil
+}
+
+// resealAttachment encrypts an attachment under the owner's active data key,
+// sealing it for the first time if it was stored before attachments were
+// encrypted at rest
+func (s *Service) resealAttachment(ctx context.Context, ownerSub string, attachment store.Attachment) error {
+	content, err := s.readAttachment(ctx, ownerSub, attachment)
+	if err != nil {
+		return fmt.Errorf("failed to read attachment %s: %w", attachment.ID, err)
+	}
+	blob, err := s.enc.Encrypt(ctx, ownerSub, content)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt attachment %s: %w", attachment.ID, err)
+	}
+
+	// Marked before the upload: until it lands, opening the plaintext object
+	// passes it through unchanged
+	if !attachment.Encrypted {
+		if err := s.store.MarkAttachmentEncrypted(ctx, attachment.ID); err != nil {
+			return fmt.Errorf("failed to mark attachment %s encrypted: %w", attachment.ID, err)
+		}
+	}
+	if err := s.s3.UploadObject(ctx, attachment.S3Key, blob, sealedAttachmentType); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", attachment.S3Key, err)
+	}
+	return nil
+}
+
+// AttachmentMarkdown returns the Markdown that references an attachment.
+// Clients resolve the attachment: scheme through the attachments endpoint.
+func AttachmentMarkdown(attachment store.Attachment) string {
+	ref := fmt.Sprintf("[%s](attachment:%s)", attachment.Filename, attachment.ID)
+	if strings.HasPrefix(attachment.ContentType, "image/") {
+		return "!" + ref
+	}
+	return ref
+}
+
+// deleteAttachmentObjects removes attachment objects from S3; their rows go
+// with the entry or journal through the ON DELETE CASCADE
+func (s *Service) deleteAttachmentObjects(ctx context.Context, attachments []store.Attachment) {
+	for _, attachment := range attachments {
+		if err := s.s3.Delete(ctx, attachment.S3Key); err != nil {
+			// Log error but continue
+			fmt.Printf("Warning: failed to delete S3 object %s: %v\n", attachment.S3Key, err)
+		}
+	}
+}
+
+// cleanFilename keeps the base name of an upload and drops characters that
+// are awkward in S3 keys and Markdown links
+func cleanFilename(filename string) string {
+	filename = path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	filename = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r == '.', r == '-', r == '_':
+			return r
+		case r == ' ':
+			return '_'
+		}
+		return -1
+	}, filename)
+	if filename == "" || filename == "." || filename == ".." {
+		return "attachment"
+	}
+	return filename
+}
+
+func randomToken(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
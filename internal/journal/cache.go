@@ -0,0 +1,115 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/telluriancorp/ll-journal/internal/cache"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// journalCacheTTL bounds how long a cached ownership check can outlive a
+// change made through another instance
+const journalCacheTTL = 30 * time.Second
+
+// WithCache caches entry content and journal ownership checks. Content is
+// cached as stored, so blobs encrypted at rest stay encrypted in the cache.
+func WithCache(c cache.Cache) Option {
+	return func(s *Service) {
+		s.cache = c
+	}
+}
+
+// CacheStats returns hit/miss counters for each cache layer
+func (s *Service) CacheStats() []cache.Stats {
+	if s.cache == nil {
+		return nil
+	}
+	return s.cache.Stats()
+}
+
+// getJournal loads a journal the user owns, going through the cache
+func (s *Service) getJournal(ctx context.Context, id, userSub string) (store.Journal, error) {
+	if s.cache == nil {
+		return s.store.GetJournal(ctx, id, userSub)
+	}
+
+	key := journalCacheKey(id, userSub)
+	if data, ok := s.cache.Get(ctx, key); ok {
+		var journal store.Journal
+		if err := json.Unmarshal(data, &journal); err == nil {
+			return journal, nil
+		}
+	}
+
+	journal, err := s.store.GetJournal(ctx, id, userSub)
+	if err != nil {
+		return store.Journal{}, err
+	}
+	if data, err := json.Marshal(journal); err == nil {
+		s.cache.Set(ctx, key, data, journalCacheTTL)
+	}
+	return journal, nil
+}
+
+// readEntryBlob downloads an entry's stored content, going through the cache.
+// The key includes the commit hash, so every update produces a fresh key.
+func (s *Service) readEntryBlob(ctx context.Context, entry store.JournalEntry) ([]byte, error) {
+	if s.cache == nil || !entry.GitCommitHash.Valid {
+		return s.s3.Download(ctx, entry.S3Key)
+	}
+
+	key := entryCacheKey(entry)
+	if blob, ok := s.cache.Get(ctx, key); ok {
+		return blob, nil
+	}
+
+	blob, err := s.s3.Download(ctx, entry.S3Key)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Set(ctx, key, blob, 0)
+	return blob, nil
+}
+
+func (s *Service) invalidateJournal(ctx context.Context, id, userSub string) {
+	if s.cache != nil {
+		s.cache.Delete(ctx, journalCacheKey(id, userSub))
+	}
+}
+
+// invalidateMembers evicts every member's cached copy of a journal
+func (s *Service) invalidateMembers(ctx context.Context, journalID string) {
+	if s.cache == nil {
+		return
+	}
+	members, err := s.store.ListJournalMembers(ctx, journalID)
+	if err != nil {
+		fmt.Printf("Warning: failed to list members for cache invalidation: %v\n", err)
+		return
+	}
+	for _, member := range members {
+		s.cache.Delete(ctx, journalCacheKey(journalID, member.UserSub))
+	}
+}
+
+func (s *Service) invalidateEntry(ctx context.Context, entry store.JournalEntry) {
+	if s.cache != nil && entry.GitCommitHash.Valid {
+		s.cache.Delete(ctx, entryCacheKey(entry))
+	}
+}
+
+func journalCacheKey(id, userSub string) string {
+	return fmt.Sprintf("journal:%s:%s", id, userSub)
+}
+
+func entryCacheKey(entry store.JournalEntry) string {
+	return fmt.Sprintf("content:%s@%s", entry.S3Key, entry.GitCommitHash.String)
+}
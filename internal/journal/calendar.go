@@ -0,0 +1,105 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// CalendarMonth lists every day of a month, with or without an entry, and
+// summarises the month's moods and tags
+type CalendarMonth struct {
+	Month   string // 2006-01
+	Days    []CalendarDay
+	Entries int64
+	Words   int64
+	Moods   []Count // most frequent first
+	Tags    []Count // most frequent first
+}
+
+type CalendarDay struct {
+	Date  string
+	Entry *store.CalendarDay // nil when nothing was written that day
+}
+
+type Count struct {
+	Value string
+	Count int64
+}
+
+// Calendar summarises a journal's entries for a month given as YYYY-MM,
+// defaulting to the current month in the user's time zone
+func (s *Service) Calendar(ctx context.Context, userSub, journalID, month string) (CalendarMonth, error) {
+	var start time.Time
+	if month == "" {
+		today, err := s.userToday(ctx, userSub)
+		if err != nil {
+			return CalendarMonth{}, err
+		}
+		start = time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	} else {
+		var err error
+		if start, err = time.Parse("2006-01", month); err != nil {
+			return CalendarMonth{}, fmt.Errorf("invalid month format: expected YYYY-MM: %w", err)
+		}
+	}
+	end := start.AddDate(0, 1, 0)
+
+	if _, err := s.authorize(ctx, journalID, userSub, RoleViewer); err != nil {
+		return CalendarMonth{}, err
+	}
+
+	entries, err := s.store.ListCalendarDays(ctx, journalID, start, end)
+	if err != nil {
+		return CalendarMonth{}, fmt.Errorf("failed to load calendar: %w", err)
+	}
+
+	calendar := CalendarMonth{Month: start.Format("2006-01")}
+	byDate := make(map[string]*store.CalendarDay, len(entries))
+	moods := make(map[string]int64)
+	tags := make(map[string]int64)
+	for i := range entries {
+		entry := &entries[i]
+		byDate[entry.Date.Format("2006-01-02")] = entry
+
+		calendar.Entries++
+		calendar.Words += int64(entry.WordCount.Int32)
+		if entry.Mood.Valid {
+			moods[entry.Mood.String]++
+		}
+		for _, tag := range entry.Tags {
+			tags[tag]++
+		}
+	}
+
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		calendar.Days = append(calendar.Days, CalendarDay{Date: date, Entry: byDate[date]})
+	}
+	calendar.Moods = sortedCounts(moods)
+	calendar.Tags = sortedCounts(tags)
+	return calendar, nil
+}
+
+// sortedCounts orders counts from most to least frequent, then alphabetically
+func sortedCounts(counts map[string]int64) []Count {
+	sorted := make([]Count, 0, len(counts))
+	for value, count := range counts {
+		sorted = append(sorted, Count{Value: value, Count: count})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].Value < sorted[j].Value
+	})
+	return sorted
+}
@@ -0,0 +1,67 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/telluriancorp/ll-journal/internal/git"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// WithCommitCoalescing makes updates to an entry by the author of its latest
+// version, within d of that version, amend its commit instead of adding one
+func WithCommitCoalescing(d time.Duration) Option {
+	return func(s *Service) {
+		if d > 0 {
+			s.commitCoalesce = d
+		}
+	}
+}
+
+// commitUpdate commits an entry update and reports whether it amended the
+// entry's latest commit. Amending needs that commit to be by the same author,
+// started within the coalescing window, still the repository's HEAD, and not
+// pinned by a share link; git history cannot be rewritten under later commits
+// or under a link that must keep resolving. The window runs from the
+// version's first save, so steady editing still produces a commit per window.
+func (s *Service) commitUpdate(ctx context.Context, journal store.Journal, entry store.JournalEntry, userSub, entryDate string, blob []byte) (string, bool, error) {
+	if s.commitCoalesce > 0 && entry.GitCommitHash.Valid {
+		version, err := s.store.GetLatestJournalVersion(ctx, entry.ID)
+		if err == nil && version.CommitHash == entry.GitCommitHash.String &&
+			version.AuthorName.String == userSub && time.Since(version.CreatedAt) < s.commitCoalesce &&
+			!s.sharePinned(ctx, entry.ID, version.CommitHash) {
+			commitHash, err := s.git.AmendFile(journal.UserSub, journal.ID, entryDate, string(blob), version.CommitHash, memberAuthor(userSub))
+			if err == nil {
+				// The old commit is gone from history, so the version follows the new one
+				if err := s.store.UpdateJournalVersionCommit(ctx, version.ID, commitHash); err != nil {
+					fmt.Printf("Warning: failed to update version %s: %v\n", version.ID, err)
+				}
+				return commitHash, true, nil
+			}
+			if !errors.Is(err, git.ErrNotHead) {
+				return "", false, err
+			}
+		}
+	}
+
+	commitHash, err := s.git.CommitFile(journal.UserSub, journal.ID, entryDate, string(blob), fmt.Sprintf("Update entry for %s", entryDate), memberAuthor(userSub))
+	return commitHash, false, err
+}
+
+// sharePinned reports whether a share link pins the commit. When the links
+// cannot be checked the commit is treated as pinned, so it is not amended.
+func (s *Service) sharePinned(ctx context.Context, entryID, commitHash string) bool {
+	pinned, err := s.store.ShareLinkPinsCommit(ctx, entryID, commitHash)
+	if err != nil {
+		fmt.Printf("Warning: failed to check share links of entry %s: %v\n", entryID, err)
+		return true
+	}
+	return pinned
+}
@@ -0,0 +1,594 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/telluriancorp/ll-journal/internal/collab"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+const (
+	// DefaultCollabCheckpointInterval is how often a live document with
+	// unsaved changes is written back through UpdateEntry
+	DefaultCollabCheckpointInterval = 30 * time.Second
+
+	// collabSendBuffer is how many messages a session may fall behind by
+	// before it is disconnected
+	collabSendBuffer = 256
+
+	// collabSaveTimeout bounds one checkpoint
+	collabSaveTimeout = 30 * time.Second
+
+	// collabRoleCheckInterval is how often the roles of a room's members are
+	// read again, so that changes made on another instance are noticed
+	collabRoleCheckInterval = 15 * time.Second
+
+	// collabSaveAttempts is how many times a checkpoint merges an entry that
+	// keeps changing under it before giving up until the next one
+	collabSaveAttempts = 3
+
+	// collabMaxSaveFailures is how many checkpoints in a row may fail before
+	// a room everyone has left is given up on
+	collabMaxSaveFailures = 5
+)
+
+// WithCollabCheckpointInterval sets how often live documents are saved
+func WithCollabCheckpointInterval(d time.Duration) Option {
+	return func(s *Service) {
+		if d > 0 {
+			s.collabCheckpoint = d
+		}
+	}
+}
+
+// collabRoom is the live document of one entry and everyone editing it. It
+// lives on the instance that loaded it. Locks are taken in the order
+// Service.collabMu, saveMu, mu.
+type collabRoom struct {
+	key       string
+	journalID string
+	entryDate string
+
+	mu       sync.Mutex
+	doc      *collab.Document
+	sessions map[string]*CollabSession
+	dirty    bool
+	editors  []string // users who changed the document, most recent first
+	failures int      // checkpoints failed in a row
+	idle     chan struct{}
+
+	// saveMu guards the entry as last loaded or saved: its commit, its text,
+	// and that text as a peer of the document, so the next outside edit can
+	// be merged in. peer is nil once that is no longer possible.
+	saveMu     sync.Mutex
+	baseCommit string
+	baseText   string
+	peer       *collab.Peer
+}
+
+// CollabSession is one connection to a live document. Messages for the
+// client arrive on Messages, which is closed when the session ends.
+type CollabSession struct {
+	ID      string
+	UserSub string
+	CanEdit bool // guarded by room.mu
+
+	room     *collabRoom
+	messages chan collab.Message
+	cursor   *collab.Cursor
+	closed   bool
+}
+
+// JoinCollab joins the live document of an entry, loading it if nobody is
+// editing it yet. Viewers may follow along; editors may change it. The
+// first message on the session is the document itself.
+func (s *Service) JoinCollab(ctx context.Context, userSub, journalID, entryDate string) (*CollabSession, error) {
+	journal, err := s.authorize(ctx, journalID, userSub, RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	// The server cannot merge edits to ciphertext
+	if journal.E2EE {
+		return nil, fmt.Errorf("invalid request: live editing is not available for end-to-end encrypted journals")
+	}
+
+	id, err := randomToken(8)
+	if err != nil {
+		return nil, err
+	}
+	session := &CollabSession{
+		ID:       id,
+		UserSub:  userSub,
+		CanEdit:  roleRank[journal.Role] >= roleRank[RoleEditor],
+		messages: make(chan collab.Message, collabSendBuffer),
+	}
+
+	// The entry is loaded without holding collabMu, so a slow download does
+	// not hold up every other room. Whoever inserts first wins; a room loaded
+	// meanwhile by someone else is joined instead.
+	key := journalID + "/" + entryDate
+	var loaded *collabRoom
+	for {
+		s.collabMu.Lock()
+		room, ok := s.collabRooms[key]
+		if !ok && loaded != nil {
+			if s.collabRooms == nil {
+				s.collabRooms = make(map[string]*collabRoom)
+			}
+			room, ok = loaded, true
+			s.collabRooms[key] = room
+			go s.runCollabRoom(room)
+		}
+		if ok {
+			room.join(session)
+			s.collabMu.Unlock()
+			return session, nil
+		}
+		s.collabMu.Unlock()
+
+		if loaded, err = s.loadCollabRoom(ctx, userSub, journalID, entryDate); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// loadCollabRoom reads an entry into a new room
+func (s *Service) loadCollabRoom(ctx context.Context, userSub, journalID, entryDate string) (*collabRoom, error) {
+	journal, entry, err := s.loadEntry(ctx, userSub, journalID, entryDate, RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	blob, err := s.readEntryBlob(ctx, entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download from S3: %w", err)
+	}
+	content, err := s.presentContent(ctx, journal, blob)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := collab.NewDocument(string(content))
+	return &collabRoom{
+		key:        journalID + "/" + entryDate,
+		journalID:  journalID,
+		entryDate:  entryDate,
+		doc:        doc,
+		sessions:   make(map[string]*CollabSession),
+		idle:       make(chan struct{}, 1),
+		baseCommit: entry.GitCommitHash.String,
+		baseText:   string(content),
+		peer:       doc.NewPeer(doc.Revision()),
+	}, nil
+}
+
+// join adds a session to the room and sends it the document
+func (r *collabRoom) join(session *CollabSession) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session.room = r
+	r.sessions[session.ID] = session
+
+	text := r.doc.Text()
+	canEdit := session.CanEdit
+	session.send(collab.Message{
+		Type:         collab.MessageInit,
+		Revision:     r.doc.Revision(),
+		Text:         &text,
+		ClientID:     session.ID,
+		CanEdit:      &canEdit,
+		Participants: r.participants(),
+	})
+	r.broadcast(session, collab.Message{
+		Type:     collab.MessageJoin,
+		ClientID: session.ID,
+		UserSub:  session.UserSub,
+		CanEdit:  &canEdit,
+	})
+}
+
+func (c *CollabSession) Messages() <-chan collab.Message {
+	return c.messages
+}
+
+// Handle processes a message from the client. Rejected messages are
+// answered with an error message rather than ending the session.
+func (c *CollabSession) Handle(msg collab.Message) {
+	room := c.room
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	switch msg.Type {
+	case collab.MessageOp:
+		if !c.CanEdit {
+			c.reject("forbidden: requires editor role")
+			return
+		}
+		if msg.Op == nil {
+			c.reject("invalid message: op is required")
+			return
+		}
+		op, err := room.doc.Apply(msg.Revision, msg.Op)
+		if err != nil {
+			c.reject(err.Error())
+			return
+		}
+		if op.IsNoop() {
+			c.send(collab.Message{Type: collab.MessageAck, Revision: room.doc.Revision()})
+			return
+		}
+
+		room.dirty = true
+		room.touch(c.UserSub)
+		room.transformCursors(op)
+		c.send(collab.Message{Type: collab.MessageAck, Revision: room.doc.Revision()})
+		room.broadcast(c, collab.Message{
+			Type:     collab.MessageOp,
+			Revision: room.doc.Revision(),
+			Op:       op,
+			ClientID: c.ID,
+			UserSub:  c.UserSub,
+		})
+
+	case collab.MessageCursor:
+		if msg.Cursor == nil {
+			c.reject("invalid message: cursor is required")
+			return
+		}
+		// Cursors are sent against the client's revision; clamp rather than
+		// transform, since the next cursor message corrects any drift
+		length := utf8.RuneCountInString(room.doc.Text())
+		cursor := collab.Cursor{
+			Position:     max(0, min(msg.Cursor.Position, length)),
+			SelectionEnd: max(0, min(msg.Cursor.SelectionEnd, length)),
+		}
+		c.cursor = &cursor
+		room.broadcast(c, collab.Message{
+			Type:     collab.MessageCursor,
+			Cursor:   &cursor,
+			ClientID: c.ID,
+			UserSub:  c.UserSub,
+		})
+
+	default:
+		c.reject(fmt.Sprintf("invalid message type %q", msg.Type))
+	}
+}
+
+// checkCollabRoles reads the role of everyone in the room again, ending the
+// sessions of those who are no longer members and updating what the others
+// may do. A failed lookup keeps the role already known.
+func (s *Service) checkCollabRoles(room *collabRoom) {
+	room.mu.Lock()
+	users := make(map[string]bool)
+	for _, session := range room.sessions {
+		users[session.UserSub] = true
+	}
+	room.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), collabSaveTimeout)
+	defer cancel()
+
+	roles := make(map[string]string, len(users))
+	for userSub := range users {
+		journal, err := s.authorize(ctx, room.journalID, userSub, RoleViewer)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			fmt.Printf("Warning: failed to check role of %s in live document %s: %v\n", userSub, room.key, err)
+			continue
+		}
+		roles[userSub] = journal.Role // empty once they are not a member
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+	for _, session := range room.sessions {
+		role, ok := roles[session.UserSub]
+		switch {
+		case !ok:
+		case role == "":
+			room.remove(session)
+		default:
+			session.CanEdit = roleRank[role] >= roleRank[RoleEditor]
+		}
+	}
+}
+
+// closeCollabSessions ends a user's live editing sessions in a journal on
+// this instance; rooms elsewhere drop them at their next role check
+func (s *Service) closeCollabSessions(journalID, userSub string) {
+	s.collabMu.Lock()
+	defer s.collabMu.Unlock()
+
+	for _, room := range s.collabRooms {
+		if room.journalID != journalID {
+			continue
+		}
+		room.mu.Lock()
+		for _, session := range room.sessions {
+			if session.UserSub == userSub {
+				room.remove(session)
+			}
+		}
+		room.mu.Unlock()
+	}
+}
+
+// Leave ends the session. The document is saved once the last session
+// leaves.
+func (c *CollabSession) Leave() {
+	room := c.room
+	room.mu.Lock()
+	defer room.mu.Unlock()
+	room.remove(c)
+}
+
+// reject answers the client with an error; room.mu must be held
+func (c *CollabSession) reject(reason string) {
+	c.send(collab.Message{Type: collab.MessageError, Error: reason})
+}
+
+// send queues a message for the client, disconnecting it if it has fallen
+// too far behind; room.mu must be held
+func (c *CollabSession) send(msg collab.Message) {
+	if c.closed {
+		return
+	}
+	select {
+	case c.messages <- msg:
+	default:
+		c.room.remove(c)
+	}
+}
+
+// remove closes a session and tells the others; mu must be held
+func (r *collabRoom) remove(c *CollabSession) {
+	if c.closed {
+		return
+	}
+	c.closed = true
+	close(c.messages)
+	delete(r.sessions, c.ID)
+
+	r.broadcast(c, collab.Message{
+		Type:     collab.MessageLeave,
+		ClientID: c.ID,
+		UserSub:  c.UserSub,
+	})
+	if len(r.sessions) == 0 {
+		select {
+		case r.idle <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// broadcast sends a message to every session but from; mu must be held
+func (r *collabRoom) broadcast(from *CollabSession, msg collab.Message) {
+	for _, session := range r.sessions {
+		if session != from {
+			session.send(msg)
+		}
+	}
+}
+
+// touch records userSub as the latest editor; mu must be held
+func (r *collabRoom) touch(userSub string) {
+	editors := []string{userSub}
+	for _, editor := range r.editors {
+		if editor != userSub {
+			editors = append(editors, editor)
+		}
+	}
+	r.editors = editors
+}
+
+// transformCursors moves every cursor past op; mu must be held
+func (r *collabRoom) transformCursors(op *collab.Operation) {
+	for _, session := range r.sessions {
+		if session.cursor != nil {
+			cursor := session.cursor.Transform(op)
+			session.cursor = &cursor
+		}
+	}
+}
+
+// participants lists the room's sessions; mu must be held
+func (r *collabRoom) participants() []collab.Participant {
+	participants := make([]collab.Participant, 0, len(r.sessions))
+	for _, session := range r.sessions {
+		participants = append(participants, collab.Participant{
+			ClientID: session.ID,
+			UserSub:  session.UserSub,
+			CanEdit:  session.CanEdit,
+			Cursor:   session.cursor,
+		})
+	}
+	return participants
+}
+
+// runCollabRoom checkpoints a room periodically and once everyone has left,
+// then closes it. Between checkpoints it checks the members' roles. A room whose last checkpoint failed stays open so the
+// next tick retries it, up to collabMaxSaveFailures times. Closing happens
+// under collabMu, so a user joining at the same time either joins this room
+// or loads the saved entry into a new one.
+func (s *Service) runCollabRoom(room *collabRoom) {
+	ticker := time.NewTicker(s.collabCheckpoint)
+	defer ticker.Stop()
+	roleTicker := time.NewTicker(collabRoleCheckInterval)
+	defer roleTicker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-room.idle:
+		case <-roleTicker.C:
+			// Removing the last session signals idle, which closes the room
+			s.checkCollabRoles(room)
+			continue
+		}
+		s.checkpointCollab(room)
+
+		s.collabMu.Lock()
+		room.mu.Lock()
+		closing := len(room.sessions) == 0 && (!room.dirty || room.failures >= collabMaxSaveFailures)
+		if closing {
+			if room.dirty {
+				fmt.Printf("Warning: discarding unsaved live document %s after %d failed checkpoints\n", room.key, room.failures)
+			}
+			delete(s.collabRooms, room.key)
+		}
+		room.mu.Unlock()
+		s.collabMu.Unlock()
+		if closing {
+			return
+		}
+	}
+}
+
+// checkpointCollab saves the document through UpdateEntry, so it lands in
+// S3 and git history like any other edit, credited to its latest editor who
+// may still edit the journal
+func (s *Service) checkpointCollab(room *collabRoom) {
+	room.saveMu.Lock()
+	defer room.saveMu.Unlock()
+
+	room.mu.Lock()
+	if !room.dirty {
+		room.mu.Unlock()
+		return
+	}
+	candidates := append([]string(nil), room.editors...)
+	for _, session := range room.sessions {
+		if session.CanEdit {
+			candidates = append(candidates, session.UserSub)
+		}
+	}
+	room.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), collabSaveTimeout)
+	defer cancel()
+
+	err := s.saveCollab(ctx, room, candidates)
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+	if err != nil {
+		fmt.Printf("Warning: failed to checkpoint live document %s: %v\n", room.key, err)
+		room.failures++
+		return
+	}
+	room.failures = 0
+}
+
+// saveCollab writes the document as the first candidate allowed to. An edit
+// saved outside the room since it was loaded is merged into the document
+// first rather than overwritten, and the write only succeeds if no other
+// edit lands meanwhile; if one does, it is merged too and the write retried.
+// saveMu must be held.
+func (s *Service) saveCollab(ctx context.Context, room *collabRoom, candidates []string) error {
+	var editor string
+	for _, userSub := range candidates {
+		if _, err := s.authorize(ctx, room.journalID, userSub, RoleEditor); err == nil {
+			editor = userSub
+			break
+		}
+	}
+	if editor == "" {
+		return fmt.Errorf("forbidden: nobody who changed the document can still edit the journal")
+	}
+
+	for attempt := 1; ; attempt++ {
+		journal, entry, err := s.loadEntry(ctx, editor, room.journalID, room.entryDate, RoleEditor)
+		if err != nil {
+			return err
+		}
+		if entry.GitCommitHash.String != room.baseCommit {
+			if err := s.rebaseCollab(ctx, room, journal, entry); err != nil {
+				return err
+			}
+		}
+
+		room.mu.Lock()
+		text, revision := room.doc.Text(), room.doc.Revision()
+		room.dirty = false
+		room.mu.Unlock()
+
+		saved, err := s.updateEntry(ctx, editor, room.journalID, room.entryDate, text, entry.ClientMetadata.String, entry.GitCommitHash.String)
+		if err != nil {
+			room.mu.Lock()
+			room.dirty = true
+			room.mu.Unlock()
+			if errors.Is(err, errEntryMoved) && attempt < collabSaveAttempts {
+				continue
+			}
+			return err
+		}
+
+		room.mu.Lock()
+		defer room.mu.Unlock()
+		room.baseCommit, room.baseText, room.peer = saved.GitCommitHash.String, text, room.doc.NewPeer(revision)
+		room.broadcast(nil, collab.Message{
+			Type:       collab.MessageSaved,
+			Revision:   revision,
+			CommitHash: saved.GitCommitHash.String,
+		})
+		return nil
+	}
+}
+
+// rebaseCollab applies the change between the room's base and the entry as
+// it is now to the document, as if a peer whose text is the base had made
+// it. If the document no longer has the history to do that, the live
+// document wins and the other edit survives only in git history. saveMu
+// must be held.
+func (s *Service) rebaseCollab(ctx context.Context, room *collabRoom, journal store.Journal, entry store.JournalEntry) error {
+	blob, err := s.readEntryBlob(ctx, entry)
+	if err != nil {
+		return fmt.Errorf("failed to download from S3: %w", err)
+	}
+	content, err := s.presentContent(ctx, journal, blob)
+	if err != nil {
+		return err
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	peer, baseText := room.peer, room.baseText
+	room.baseCommit, room.baseText, room.peer = entry.GitCommitHash.String, string(content), nil
+
+	if peer != nil {
+		op, err := room.doc.ApplyPeer(peer, collab.Diff(baseText, string(content)))
+		if err == nil {
+			// The peer now holds the entry's text, so the next edit merges too
+			room.peer = peer
+			if !op.IsNoop() {
+				room.transformCursors(op)
+				room.broadcast(nil, collab.Message{
+					Type:     collab.MessageOp,
+					Revision: room.doc.Revision(),
+					Op:       op,
+				})
+			}
+			return nil
+		}
+		fmt.Printf("Warning: failed to merge commit %s into live document %s: %v\n", entry.GitCommitHash.String, room.key, err)
+	}
+	fmt.Printf("Warning: live document %s overwrites commit %s\n", room.key, entry.GitCommitHash.String)
+	return nil
+}
@@ -0,0 +1,184 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/telluriancorp/ll-journal/internal/s3"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// SaveDraft stores work in progress on a day's entry without committing it.
+// Each member has one draft per day, overwritten by every save; the entry
+// itself and its history are untouched until the draft is published.
+func (s *Service) SaveDraft(ctx context.Context, userSub, journalID, entryDate, content, clientMetadata string) (store.EntryDraft, error) {
+	date, err := time.Parse("2006-01-02", entryDate)
+	if err != nil {
+		return store.EntryDraft{}, fmt.Errorf("invalid date format: %w", err)
+	}
+	journal, err := s.authorize(ctx, journalID, userSub, RoleEditor)
+	if err != nil {
+		return store.EntryDraft{}, err
+	}
+
+	blob, facts, err := s.prepareContent(ctx, journal, content, clientMetadata)
+	if err != nil {
+		return store.EntryDraft{}, err
+	}
+
+	draft := store.EntryDraft{
+		JournalID:      journalID,
+		EntryDate:      date,
+		UserSub:        userSub,
+		S3Key:          s3.GenerateDraftKey(journal.UserSub, journalID, entryDate, userSub),
+		ClientMetadata: sql.NullString{String: clientMetadata, Valid: clientMetadata != ""},
+		WordCount:      facts.WordCount,
+	}
+	// Only kept from the first save, so publishing can tell whether the entry
+	// moved on while the draft was being written
+	if entry, err := s.store.GetJournalEntryByDate(ctx, journalID, date); err == nil {
+		draft.BaseCommitHash = entry.GitCommitHash
+	}
+
+	if err := s.s3.Upload(ctx, draft.S3Key, blob); err != nil {
+		return store.EntryDraft{}, fmt.Errorf("failed to upload to S3: %w", err)
+	}
+	saved, err := s.store.SaveEntryDraft(ctx, draft)
+	if err != nil {
+		return store.EntryDraft{}, fmt.Errorf("failed to save draft: %w", err)
+	}
+	return saved, nil
+}
+
+// GetDraft returns the user's draft of a day's entry with its content. Drafts
+// stay readable to members who can no longer edit, so work is not lost.
+func (s *Service) GetDraft(ctx context.Context, userSub, journalID, entryDate string) (store.EntryDraft, []byte, error) {
+	journal, draft, err := s.loadDraft(ctx, userSub, journalID, entryDate)
+	if err != nil {
+		return store.EntryDraft{}, nil, err
+	}
+
+	blob, err := s.s3.Download(ctx, draft.S3Key)
+	if err != nil {
+		return store.EntryDraft{}, nil, fmt.Errorf("failed to download from S3: %w", err)
+	}
+	content, err := s.presentContent(ctx, journal, blob)
+	if err != nil {
+		return store.EntryDraft{}, nil, err
+	}
+	return draft, content, nil
+}
+
+// ListDrafts lists the user's drafts in a journal
+func (s *Service) ListDrafts(ctx context.Context, userSub, journalID string) ([]store.EntryDraft, error) {
+	if _, err := s.authorize(ctx, journalID, userSub, RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.store.ListEntryDrafts(ctx, journalID, userSub)
+}
+
+// DiscardDraft deletes the user's draft of a day's entry
+func (s *Service) DiscardDraft(ctx context.Context, userSub, journalID, entryDate string) error {
+	_, draft, err := s.loadDraft(ctx, userSub, journalID, entryDate)
+	if err != nil {
+		return err
+	}
+	return s.deleteDraft(ctx, draft)
+}
+
+// PublishDraft saves the user's draft as the day's entry in a single commit,
+// creating the entry if there is none, and deletes the draft. If the entry
+// changed since the draft was started, publishing fails with a conflict
+// unless force is set.
+func (s *Service) PublishDraft(ctx context.Context, userSub, journalID, entryDate string, force bool) (store.JournalEntry, error) {
+	journal, draft, err := s.loadDraft(ctx, userSub, journalID, entryDate)
+	if err != nil {
+		return store.JournalEntry{}, err
+	}
+	if _, err := s.authorize(ctx, journalID, userSub, RoleEditor); err != nil {
+		return store.JournalEntry{}, err
+	}
+
+	entry, err := s.store.GetJournalEntryByDate(ctx, journalID, draft.EntryDate)
+	exists := err == nil
+	if !force && (exists != draft.BaseCommitHash.Valid ||
+		(exists && entry.GitCommitHash.String != draft.BaseCommitHash.String)) {
+		return store.JournalEntry{}, fmt.Errorf("draft conflict: the entry for %s changed since the draft was started", entryDate)
+	}
+
+	blob, err := s.s3.Download(ctx, draft.S3Key)
+	if err != nil {
+		return store.JournalEntry{}, fmt.Errorf("failed to download from S3: %w", err)
+	}
+	content, err := s.presentContent(ctx, journal, blob)
+	if err != nil {
+		return store.JournalEntry{}, err
+	}
+
+	if exists {
+		// Unless forced, the base is checked again as the entry is written
+		baseCommit := ""
+		if !force {
+			baseCommit = draft.BaseCommitHash.String
+		}
+		entry, err = s.updateEntry(ctx, userSub, journalID, entryDate, string(content), draft.ClientMetadata.String, baseCommit)
+	} else {
+		entry, err = s.CreateEntry(ctx, userSub, journalID, entryDate, string(content), "", draft.ClientMetadata.String)
+	}
+	if errors.Is(err, errEntryMoved) {
+		return store.JournalEntry{}, fmt.Errorf("draft conflict: the entry for %s changed since the draft was started", entryDate)
+	}
+	if err != nil {
+		return store.JournalEntry{}, err
+	}
+
+	// The entry is saved, so a leftover draft is only clutter
+	if err := s.deleteDraft(ctx, draft); err != nil {
+		fmt.Printf("Warning: failed to delete published draft %s: %v\n", draft.ID, err)
+	}
+	return entry, nil
+}
+
+// loadDraft loads the user's draft of a day after checking they can still see
+// the journal
+func (s *Service) loadDraft(ctx context.Context, userSub, journalID, entryDate string) (store.Journal, store.EntryDraft, error) {
+	date, err := time.Parse("2006-01-02", entryDate)
+	if err != nil {
+		return store.Journal{}, store.EntryDraft{}, fmt.Errorf("invalid date format: %w", err)
+	}
+	journal, err := s.authorize(ctx, journalID, userSub, RoleViewer)
+	if err != nil {
+		return store.Journal{}, store.EntryDraft{}, err
+	}
+
+	draft, err := s.store.GetEntryDraft(ctx, journalID, date, userSub)
+	if err != nil {
+		return store.Journal{}, store.EntryDraft{}, fmt.Errorf("draft not found: %w", err)
+	}
+	return journal, draft, nil
+}
+
+func (s *Service) deleteDraft(ctx context.Context, draft store.EntryDraft) error {
+	if err := s.store.DeleteEntryDraft(ctx, draft.ID); err != nil {
+		return fmt.Errorf("failed to delete draft: %w", err)
+	}
+	s.deleteDraftObjects(ctx, []store.EntryDraft{draft})
+	return nil
+}
+
+// deleteDraftObjects removes drafts' content from S3, logging failures
+func (s *Service) deleteDraftObjects(ctx context.Context, drafts []store.EntryDraft) {
+	for _, draft := range drafts {
+		if err := s.s3.Delete(ctx, draft.S3Key); err != nil {
+			fmt.Printf("Warning: failed to delete S3 object %s: %v\n", draft.S3Key, err)
+		}
+	}
+}
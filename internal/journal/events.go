@@ -0,0 +1,50 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/telluriancorp/ll-journal/internal/events"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// WithEvents publishes domain events to p once changes are committed. It
+// may be given more than once; every publisher receives every event.
+func WithEvents(p events.Publisher) Option {
+	return func(s *Service) {
+		s.publishers = append(s.publishers, p)
+	}
+}
+
+// emit publishes an event about a committed change to the journal's members
+// and audience. Failing to publish never fails the change that caused it.
+func (s *Service) emit(ctx context.Context, eventType, journalID, actor string, data map[string]interface{}, audience ...string) {
+	if len(s.publishers) == 0 {
+		return
+	}
+	event, err := events.New(eventType, journalID, actor, data)
+	if err == nil {
+		event.Audience = audience
+		err = s.publishers.Publish(ctx, event)
+	}
+	if err != nil {
+		fmt.Printf("Warning: failed to publish %s event for journal %s: %v\n", eventType, journalID, err)
+	}
+}
+
+// entryEventData describes an entry in events, without its content
+func entryEventData(entry store.JournalEntry) map[string]interface{} {
+	data := map[string]interface{}{
+		"entry_id":   entry.ID,
+		"entry_date": entry.EntryDate.Format("2006-01-02"),
+	}
+	if entry.GitCommitHash.Valid {
+		data["commit_hash"] = entry.GitCommitHash.String
+	}
+	return data
+}
@@ -0,0 +1,139 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"database/sql"
+	"regexp"
+	"strings"
+
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+const (
+	maxMoodLength = 64
+	maxTagLength  = 64
+	maxTags       = 50
+)
+
+// hashtagPattern matches #tags in running text. Headings ("# Title") and
+// numbers ("#1") do not match because a tag must start with a letter.
+var hashtagPattern = regexp.MustCompile(`(?:^|[\s(])#([\p{L}_][\p{L}\p{N}_/-]*)`)
+
+// entryFacts is what the server records in Postgres about an entry's content
+type entryFacts struct {
+	WordCount sql.NullInt32
+	Mood      sql.NullString
+	Tags      []string
+}
+
+func (f entryFacts) apply(entry *store.JournalEntry) {
+	entry.WordCount = f.WordCount
+	entry.Mood = f.Mood
+	entry.Tags = f.Tags
+}
+
+// extractFacts reads an entry's Markdown. Mood and tags come from a leading
+// front matter block:
+//
+//	---
+//	mood: happy
+//	tags: [travel, family]
+//	---
+//
+// Tags may also be a comma separated value or a "- item" list, and #hashtags
+// anywhere outside code add to them.
+func extractFacts(content string) entryFacts {
+	frontMatter, body := splitFrontMatter(content)
+
+	facts := entryFacts{WordCount: sql.NullInt32{Int32: int32(countWords(content)), Valid: true}}
+	var tags []string
+	var inTags bool
+	for _, line := range strings.Split(frontMatter, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if inTags && strings.HasPrefix(trimmed, "- ") {
+			tags = append(tags, strings.TrimSpace(strings.TrimPrefix(trimmed, "- ")))
+			continue
+		}
+		inTags = false
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "mood":
+			facts.Mood = normalizeMood(unquote(value))
+		case "tags":
+			if value == "" {
+				inTags = true
+				continue
+			}
+			value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+			tags = append(tags, strings.Split(value, ",")...)
+		}
+	}
+
+	for _, match := range hashtagPattern.FindAllStringSubmatch(codePattern.ReplaceAllString(body, ""), -1) {
+		tags = append(tags, match[1])
+	}
+	facts.Tags = normalizeTags(tags)
+	return facts
+}
+
+// splitFrontMatter separates a leading "---" block from the rest of the entry
+func splitFrontMatter(content string) (string, string) {
+	if !strings.HasPrefix(content, "---\n") {
+		return "", content
+	}
+	rest := content[len("---\n"):]
+	if strings.HasPrefix(rest, "---\n") {
+		return "", rest[len("---\n"):]
+	}
+	end := strings.Index(rest, "\n---\n")
+	if end < 0 {
+		if strings.HasSuffix(rest, "\n---") {
+			return rest[:len(rest)-len("\n---")], ""
+		}
+		return "", content
+	}
+	return rest[:end], rest[end+len("\n---\n"):]
+}
+
+func normalizeMood(mood string) sql.NullString {
+	mood = strings.ToLower(strings.TrimSpace(mood))
+	if mood == "" || len(mood) > maxMoodLength {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: mood, Valid: true}
+}
+
+// normalizeTags lowercases tags, drops empty, overlong and repeated ones,
+// and keeps the order they first appeared in
+func normalizeTags(raw []string) []string {
+	seen := make(map[string]bool)
+	tags := []string{}
+	for _, tag := range raw {
+		tag = strings.ToLower(strings.TrimPrefix(unquote(strings.TrimSpace(tag)), "#"))
+		if tag == "" || len(tag) > maxTagLength || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+		if len(tags) == maxTags {
+			break
+		}
+	}
+	return tags
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
@@ -0,0 +1,211 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/telluriancorp/ll-journal/internal/ical"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+const (
+	// untitledEntry is the event title of entries without a heading or text
+	untitledEntry = "Journal entry"
+
+	// feedTitleLength is the maximum number of characters in an event title
+	feedTitleLength = 100
+
+	// calendarCacheTTL is how long a rendered feed is cached. The key names
+	// the journal's state, so a changed journal is never served stale.
+	calendarCacheTTL = 24 * time.Hour
+)
+
+// CalendarDocument is a rendered feed. ETag changes whenever the journal or
+// any of its entries does, and is empty when an entry could not be read. Body
+// is nil when NotModified.
+type CalendarDocument struct {
+	Body         []byte
+	ETag         string
+	LastModified time.Time
+	NotModified  bool
+}
+
+// CreateCalendarFeed creates a secret ICS feed of a journal for the user and
+// returns it with its token. Only the token's hash is stored, so it cannot
+// be shown again.
+func (s *Service) CreateCalendarFeed(ctx context.Context, userSub, journalID string) (store.CalendarFeed, string, error) {
+	journal, err := s.authorize(ctx, journalID, userSub, RoleViewer)
+	if err != nil {
+		return store.CalendarFeed{}, "", err
+	}
+	if journal.E2EE {
+		return store.CalendarFeed{}, "", fmt.Errorf("invalid feed: end-to-end encrypted journals cannot be published as a calendar")
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return store.CalendarFeed{}, "", err
+	}
+
+	feed, err := s.store.CreateCalendarFeed(ctx, store.CalendarFeed{
+		JournalID: journalID,
+		UserSub:   userSub,
+		TokenHash: hashShareToken(token),
+	})
+	if err != nil {
+		return store.CalendarFeed{}, "", fmt.Errorf("failed to create calendar feed: %w", err)
+	}
+	return feed, token, nil
+}
+
+// ListCalendarFeeds lists the user's feeds of a journal, including revoked ones
+func (s *Service) ListCalendarFeeds(ctx context.Context, userSub, journalID string) ([]store.CalendarFeed, error) {
+	if _, err := s.authorize(ctx, journalID, userSub, RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.store.ListCalendarFeeds(ctx, journalID, userSub)
+}
+
+// RevokeCalendarFeed stops one of the user's feeds from working
+func (s *Service) RevokeCalendarFeed(ctx context.Context, userSub, journalID, feedID string) error {
+	if _, err := s.authorize(ctx, journalID, userSub, RoleViewer); err != nil {
+		return err
+	}
+
+	revoked, err := s.store.RevokeCalendarFeed(ctx, journalID, userSub, feedID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke calendar feed: %w", err)
+	}
+	if !revoked {
+		return fmt.Errorf("calendar feed not found")
+	}
+	return nil
+}
+
+// CalendarFeed renders the journal behind a feed token as an iCalendar
+// document with one all-day event per entry. The feed stops working when it
+// is revoked or its creator is no longer a member of the journal. If
+// ifNoneMatch holds the current ETag, nothing is rendered. Renderings are
+// cached by journal state, so polls of an unchanged journal read no entries.
+func (s *Service) CalendarFeed(ctx context.Context, token, ifNoneMatch string) (CalendarDocument, error) {
+	feed, err := s.store.GetCalendarFeedByTokenHash(ctx, hashShareToken(token))
+	if err != nil || feed.RevokedAt.Valid {
+		return CalendarDocument{}, fmt.Errorf("calendar feed not found")
+	}
+	journal, err := s.authorize(ctx, feed.JournalID, feed.UserSub, RoleViewer)
+	if err != nil || journal.E2EE {
+		return CalendarDocument{}, fmt.Errorf("calendar feed not found")
+	}
+
+	entries, err := s.store.ListJournalEntries(ctx, journal.ID)
+	if err != nil {
+		return CalendarDocument{}, fmt.Errorf("failed to list entries: %w", err)
+	}
+
+	if err := s.store.TouchCalendarFeed(ctx, feed.ID); err != nil {
+		fmt.Printf("Warning: failed to record calendar feed access %s: %v\n", feed.ID, err)
+	}
+
+	doc := calendarVersion(journal, entries)
+	if ifNoneMatch != "" && (ifNoneMatch == "*" || strings.Contains(ifNoneMatch, doc.ETag)) {
+		doc.NotModified = true
+		return doc, nil
+	}
+
+	// Cached renderings hold entry text, so they are sealed like entries
+	key := "calendar:" + journal.ID + ":" + doc.ETag
+	if s.cache != nil {
+		if blob, ok := s.cache.Get(ctx, key); ok {
+			if body, err := s.open(ctx, journal.UserSub, blob); err == nil {
+				doc.Body = body
+				return doc, nil
+			}
+		}
+	}
+
+	body, complete := s.renderCalendar(ctx, journal, entries)
+	doc.Body = body
+	if !complete {
+		// A retry may load the missing entries, so the degraded rendering is
+		// neither cached nor given a validator clients would revalidate with
+		doc.ETag, doc.LastModified = "", time.Time{}
+		return doc, nil
+	}
+	if s.cache != nil {
+		if blob, err := s.seal(ctx, journal.UserSub, string(doc.Body)); err == nil {
+			s.cache.Set(ctx, key, blob, calendarCacheTTL)
+		}
+	}
+	return doc, nil
+}
+
+// calendarVersion derives a feed's ETag and modification time from the
+// journal and its entries, without reading any content
+func calendarVersion(journal store.Journal, entries []store.JournalEntry) CalendarDocument {
+	sum := sha256.New()
+	fmt.Fprintf(sum, "%s\x00%s\x00%s\x00%d\n", journal.ID, journal.Title, journal.Description.String, journal.UpdatedAt.UnixNano())
+	modified := journal.UpdatedAt
+	for _, entry := range entries {
+		fmt.Fprintf(sum, "%s\x00%s\x00%d\n", entry.ID, entry.GitCommitHash.String, entry.UpdatedAt.UnixNano())
+		if entry.UpdatedAt.After(modified) {
+			modified = entry.UpdatedAt
+		}
+	}
+	return CalendarDocument{
+		ETag:         `"` + hex.EncodeToString(sum.Sum(nil)[:16]) + `"`,
+		LastModified: modified,
+	}
+}
+
+// renderCalendar builds the iCalendar document for a journal's entries. It
+// reports whether every entry could be read; the others are listed untitled.
+func (s *Service) renderCalendar(ctx context.Context, journal store.Journal, entries []store.JournalEntry) ([]byte, bool) {
+	complete := true
+	calendar := ical.Calendar{
+		ProductID:   "-//Tellurian Corp//LL-Journal//EN",
+		Name:        journal.Title,
+		Description: journal.Description.String,
+		Events:      make([]ical.Event, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		event := ical.Event{
+			UID:      entry.ID + "@lifelogger.life",
+			Date:     entry.EntryDate,
+			Summary:  untitledEntry,
+			Created:  entry.CreatedAt,
+			Modified: entry.UpdatedAt,
+		}
+		// A missing excerpt should not drop the day from the calendar
+		if content, err := s.entryText(ctx, journal, entry); err == nil {
+			event.Summary, event.Description = entrySummary(content)
+		} else {
+			fmt.Printf("Warning: failed to load entry %s for calendar feed: %v\n", entry.ID, err)
+			complete = false
+		}
+		calendar.Events = append(calendar.Events, event)
+	}
+	return calendar.Marshal(), complete
+}
+
+// entrySummary splits an entry into a title, its first heading or line, and
+// an excerpt of the text that follows. Front matter is skipped.
+func entrySummary(content string) (string, string) {
+	_, body := splitFrontMatter(content)
+	body = strings.TrimSpace(body)
+
+	title, rest, _ := strings.Cut(body, "\n")
+	title = strings.TrimSpace(strings.TrimLeft(title, "#"))
+	if title == "" {
+		return untitledEntry, excerpt(body, excerptLength)
+	}
+	return excerpt(title, feedTitleLength), excerpt(rest, excerptLength)
+}
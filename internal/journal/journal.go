@@ -6,64 +6,192 @@
 package journal
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/telluriancorp/ll-journal/internal/cache"
+	"github.com/telluriancorp/ll-journal/internal/encryption"
+	"github.com/telluriancorp/ll-journal/internal/events"
 	"github.com/telluriancorp/ll-journal/internal/git"
 	"github.com/telluriancorp/ll-journal/internal/s3"
 	"github.com/telluriancorp/ll-journal/internal/store"
+	"github.com/telluriancorp/ll-journal/internal/webhooks"
 )
 
 type Service struct {
 	store *store.Store
 	s3    *s3.Client
 	git   *git.Client
+	enc   *encryption.Envelope
+	cache cache.Cache
+
+	publishers events.Multi
+	eventLog   *events.Log
+	webhooks   *webhooks.Dispatcher
+	admins     map[string]bool
+
+	collabMu         sync.Mutex
+	collabRooms      map[string]*collabRoom
+	collabCheckpoint time.Duration
+
+	commitCoalesce time.Duration
+
+	attachmentMaxBytes int64
 }
 
-func NewService(store *store.Store, s3Client *s3.Client, gitClient *git.Client) *Service {
-	return &Service{
+// Option configures optional Service dependencies
+type Option func(*Service)
+
+// WithEncryption encrypts entry content at rest in S3 and Git
+func WithEncryption(enc *encryption.Envelope) Option {
+	return func(s *Service) {
+		s.enc = enc
+	}
+}
+
+func NewService(store *store.Store, s3Client *s3.Client, gitClient *git.Client, opts ...Option) *Service {
+	s := &Service{
 		store: store,
 		s3:    s3Client,
 		git:   gitClient,
+
+		attachmentMaxBytes: DefaultAttachmentMaxBytes,
+		collabCheckpoint:   DefaultCollabCheckpointInterval,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
-// CreateJournal creates a new journal
-func (s *Service) CreateJournal(ctx context.Context, userSub, title, description string) (store.Journal, error) {
+// CreateJournal creates a new journal. Passing a key envelope creates an
+// end-to-end encrypted journal whose entries the server cannot read.
+func (s *Service) CreateJournal(ctx context.Context, userSub, title, description string, envelope *store.JournalKeyEnvelope) (store.Journal, error) {
 	journal := store.Journal{
 		UserSub:     userSub,
 		Title:       title,
 		Description: sql.NullString{String: description, Valid: description != ""},
 	}
-	return s.store.CreateJournal(ctx, journal)
+	var err error
+	if envelope != nil {
+		journal, err = s.store.CreateE2EEJournal(ctx, journal, *envelope)
+	} else {
+		journal, err = s.store.CreateJournal(ctx, journal)
+	}
+	if err != nil {
+		return store.Journal{}, err
+	}
+
+	s.emit(ctx, events.JournalCreated, journal.ID, userSub, map[string]interface{}{
+		"title": journal.Title,
+		"e2ee":  journal.E2EE,
+	})
+	return journal, nil
+}
+
+// GetKeyEnvelope gets the client-wrapped key of an end-to-end encrypted journal
+func (s *Service) GetKeyEnvelope(ctx context.Context, journalID, userSub string) (store.JournalKeyEnvelope, error) {
+	journal, err := s.authorize(ctx, journalID, userSub, RoleViewer)
+	if err != nil {
+		return store.JournalKeyEnvelope{}, err
+	}
+	if !journal.E2EE {
+		return store.JournalKeyEnvelope{}, fmt.Errorf("key envelope not found: journal is not end-to-end encrypted")
+	}
+
+	envelope, err := s.store.GetKeyEnvelope(ctx, journalID)
+	if err != nil {
+		return store.JournalKeyEnvelope{}, fmt.Errorf("key envelope not found: %w", err)
+	}
+	return envelope, nil
+}
+
+// UpdateKeyEnvelope replaces the client-wrapped key of an end-to-end encrypted journal
+func (s *Service) UpdateKeyEnvelope(ctx context.Context, journalID, userSub string, envelope store.JournalKeyEnvelope) (store.JournalKeyEnvelope, error) {
+	journal, err := s.authorize(ctx, journalID, userSub, RoleOwner)
+	if err != nil {
+		return store.JournalKeyEnvelope{}, err
+	}
+	if !journal.E2EE {
+		return store.JournalKeyEnvelope{}, fmt.Errorf("key envelope not found: journal is not end-to-end encrypted")
+	}
+
+	envelope.JournalID = journalID
+	if err := s.store.UpdateKeyEnvelope(ctx, envelope); err != nil {
+		return store.JournalKeyEnvelope{}, fmt.Errorf("failed to update key envelope: %w", err)
+	}
+	return s.store.GetKeyEnvelope(ctx, journalID)
 }
 
 // GetJournal gets a journal by ID
 func (s *Service) GetJournal(ctx context.Context, id, userSub string) (store.Journal, error) {
-	return s.store.GetJournal(ctx, id, userSub)
+	return s.authorize(ctx, id, userSub, RoleViewer)
 }
 
-// ListJournals lists all journals for a user
+// ListJournals lists all journals the user is a member of
 func (s *Service) ListJournals(ctx context.Context, userSub string) ([]store.Journal, error) {
 	return s.store.ListJournals(ctx, userSub)
 }
 
-// UpdateJournal updates a journal
+// UpdateJournal updates a journal's title and description
 func (s *Service) UpdateJournal(ctx context.Context, id, userSub, title, description string) error {
+	existing, err := s.authorize(ctx, id, userSub, RoleOwner)
+	if err != nil {
+		return err
+	}
+
 	journal := store.Journal{
 		ID:          id,
-		UserSub:     userSub,
+		UserSub:     existing.UserSub,
 		Title:       title,
 		Description: sql.NullString{String: description, Valid: description != ""},
 	}
-	return s.store.UpdateJournal(ctx, journal)
+	if err := s.store.UpdateJournal(ctx, journal); err != nil {
+		return err
+	}
+	s.invalidateMembers(ctx, id)
+
+	s.emit(ctx, events.JournalUpdated, id, userSub, map[string]interface{}{
+		"title": title,
+	})
+	return nil
 }
 
 // DeleteJournal deletes a journal and all its entries
 func (s *Service) DeleteJournal(ctx context.Context, id, userSub string) error {
+	// Only the owner may delete, checked before touching S3
+	journal, err := s.authorize(ctx, id, userSub, RoleOwner)
+	if err != nil {
+		return err
+	}
+	members, err := s.store.ListJournalMembers(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to list members: %w", err)
+	}
+
+	// Delete attachments from S3
+	attachments, err := s.store.ListJournalAttachments(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to list attachments: %w", err)
+	}
+	s.deleteAttachmentObjects(ctx, attachments)
+
+	// Delete every member's drafts from S3
+	drafts, err := s.store.ListJournalDrafts(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to list drafts: %w", err)
+	}
+	s.deleteDraftObjects(ctx, drafts)
+
 	// Get all entries first to delete from S3
 	entries, err := s.store.ListJournalEntries(ctx, id)
 	if err != nil {
@@ -73,7 +201,7 @@ func (s *Service) DeleteJournal(ctx context.Context, id, userSub string) error {
 	// Delete entries from S3
 	for _, entry := range entries {
 		entryDate := entry.EntryDate.Format("2006-01-02")
-		s3Key := s3.GenerateKey(userSub, id, entryDate)
+		s3Key := s3.GenerateKey(journal.UserSub, id, entryDate)
 		if err := s.s3.Delete(ctx, s3Key); err != nil {
 			// Log error but continue
 			fmt.Printf("Warning: failed to delete S3 object %s: %v\n", s3Key, err)
@@ -81,21 +209,41 @@ func (s *Service) DeleteJournal(ctx context.Context, id, userSub string) error {
 	}
 
 	// Delete from database (cascade will handle entries and versions)
-	return s.store.DeleteJournal(ctx, id, userSub)
+	if err := s.store.DeleteJournal(ctx, id, journal.UserSub); err != nil {
+		return err
+	}
+	for _, member := range members {
+		s.invalidateJournal(ctx, id, member.UserSub)
+		s.invalidateUserStats(ctx, member.UserSub)
+	}
+	for _, entry := range entries {
+		s.invalidateEntry(ctx, entry)
+	}
+
+	// The members are gone with the journal, so name them explicitly
+	audience := make([]string, len(members))
+	for i, member := range members {
+		audience[i] = member.UserSub
+	}
+	s.emit(ctx, events.JournalDeleted, id, userSub, nil, audience...)
+	return nil
 }
 
-// CreateEntry creates a new journal entry
-func (s *Service) CreateEntry(ctx context.Context, userSub, journalID, entryDate, content string) (store.JournalEntry, error) {
+// CreateEntry creates a new journal entry. For end-to-end encrypted journals
+// content is base64 ciphertext and clientMetadata carries what the server
+// would otherwise derive from it. Without content the entry starts from
+// templateID, or else from the journal's default template.
+func (s *Service) CreateEntry(ctx context.Context, userSub, journalID, entryDate, content, templateID, clientMetadata string) (store.JournalEntry, error) {
 	// Validate date format
 	date, err := time.Parse("2006-01-02", entryDate)
 	if err != nil {
 		return store.JournalEntry{}, fmt.Errorf("invalid date format: %w", err)
 	}
 
-	// Validate journal exists and belongs to user
-	_, err = s.store.GetJournal(ctx, journalID, userSub)
+	// Validate journal exists and the user may write to it
+	journal, err := s.authorize(ctx, journalID, userSub, RoleEditor)
 	if err != nil {
-		return store.JournalEntry{}, fmt.Errorf("journal not found: %w", err)
+		return store.JournalEntry{}, err
 	}
 
 	// Check if entry already exists
@@ -104,22 +252,28 @@ func (s *Service) CreateEntry(ctx context.Context, userSub, journalID, entryDate
 		return store.JournalEntry{}, fmt.Errorf("entry for date %s already exists", entryDate)
 	}
 
-	// Sanitize content
-	content = sanitizeMarkdown(content)
+	if content == "" {
+		if content, err = s.initialContent(ctx, userSub, journal, templateID, date); err != nil {
+			return store.JournalEntry{}, err
+		}
+	}
 
-	// Calculate word count
-	wordCount := countWords(content)
+	// Sanitize, count and encrypt content for storage
+	blob, facts, err := s.prepareContent(ctx, journal, content, clientMetadata)
+	if err != nil {
+		return store.JournalEntry{}, err
+	}
 
 	// Generate S3 key
-	s3Key := s3.GenerateKey(userSub, journalID, entryDate)
+	s3Key := s3.GenerateKey(journal.UserSub, journalID, entryDate)
 
 	// Upload to S3
-	if err := s.s3.Upload(ctx, s3Key, []byte(content)); err != nil {
+	if err := s.s3.Upload(ctx, s3Key, blob); err != nil {
 		return store.JournalEntry{}, fmt.Errorf("failed to upload to S3: %w", err)
 	}
 
 	// Commit to Git
-	commitHash, err := s.git.CommitFile(userSub, journalID, entryDate, content, fmt.Sprintf("Entry for %s", entryDate))
+	commitHash, err := s.git.CommitFile(journal.UserSub, journalID, entryDate, string(blob), fmt.Sprintf("Entry for %s", entryDate), memberAuthor(userSub))
 	if err != nil {
 		// Try to delete from S3 if Git commit fails
 		_ = s.s3.Delete(ctx, s3Key)
@@ -128,11 +282,22 @@ func (s *Service) CreateEntry(ctx context.Context, userSub, journalID, entryDate
 
 	// Save to database
 	entry := store.JournalEntry{
-		JournalID:     journalID,
-		EntryDate:     date,
-		S3Key:         s3Key,
-		GitCommitHash: sql.NullString{String: commitHash, Valid: true},
-		WordCount:     sql.NullInt32{Int32: int32(wordCount), Valid: true},
+		JournalID:      journalID,
+		EntryDate:      date,
+		S3Key:          s3Key,
+		GitCommitHash:  sql.NullString{String: commitHash, Valid: true},
+		ClientMetadata: sql.NullString{String: clientMetadata, Valid: clientMetadata != ""},
+	}
+	facts.apply(&entry)
+
+	// Record the author's local time so the entry shows when it was written
+	// where they were, not just in UTC
+	if location, err := s.userLocation(ctx, userSub); err != nil {
+		fmt.Printf("Warning: failed to load time zone for %s: %v\n", userSub, err)
+	} else {
+		_, offset := time.Now().In(location).Zone()
+		entry.CreatedTimeZone = sql.NullString{String: location.String(), Valid: true}
+		entry.CreatedUTCOffset = sql.NullInt32{Int32: int32(offset), Valid: true}
 	}
 
 	createdEntry, err := s.store.CreateJournalEntry(ctx, entry)
@@ -147,8 +312,7 @@ func (s *Service) CreateEntry(ctx context.Context, userSub, journalID, entryDate
 		EntryID:       createdEntry.ID,
 		CommitHash:    commitHash,
 		CommitMessage: sql.NullString{String: fmt.Sprintf("Entry for %s", entryDate), Valid: true},
-		AuthorName:    sql.NullString{String: "LifeLogger System", Valid: true},
-		AuthorEmail:   sql.NullString{String: "system@lifelogger.life", Valid: true},
+		AuthorName:    sql.NullString{String: userSub, Valid: true},
 		CreatedAt:     time.Now(),
 	}
 	_, err = s.store.CreateJournalVersion(ctx, version)
@@ -157,6 +321,17 @@ func (s *Service) CreateEntry(ctx context.Context, userSub, journalID, entryDate
 		fmt.Printf("Warning: failed to save version: %v\n", err)
 	}
 
+	s.invalidateStats(ctx, journalID)
+
+	// Record this entry's links and resolve links already made to its date
+	s.updateLinks(ctx, userSub, journal, createdEntry, content)
+	if err := s.store.ResolveEntryLinks(ctx, journalID, date, createdEntry.ID); err != nil {
+		fmt.Printf("Warning: failed to resolve links to entry %s: %v\n", createdEntry.ID, err)
+	}
+
+	s.emit(ctx, events.EntryCreated, journalID, userSub, entryEventData(createdEntry))
+	s.emit(ctx, events.VersionCreated, journalID, userSub, entryEventData(createdEntry))
+
 	return createdEntry, nil
 }
 
@@ -174,23 +349,68 @@ func (s *Service) GetEntry(ctx context.Context, userSub, journalID, entryDate st
 		return store.JournalEntry{}, nil, fmt.Errorf("entry not found: %w", err)
 	}
 
-	// Verify journal belongs to user
-	_, err = s.store.GetJournal(ctx, journalID, userSub)
+	// Verify the user is a member of the journal
+	journal, err := s.authorize(ctx, journalID, userSub, RoleViewer)
 	if err != nil {
-		return store.JournalEntry{}, nil, fmt.Errorf("journal not found: %w", err)
+		return store.JournalEntry{}, nil, err
 	}
 
 	// Download from S3
-	content, err := s.s3.Download(ctx, entry.S3Key)
+	blob, err := s.readEntryBlob(ctx, entry)
 	if err != nil {
 		return store.JournalEntry{}, nil, fmt.Errorf("failed to download from S3: %w", err)
 	}
 
+	content, err := s.presentContent(ctx, journal, blob)
+	if err != nil {
+		return store.JournalEntry{}, nil, err
+	}
+
 	return entry, content, nil
 }
 
+// OpenEntryContent returns a reader over an entry's stored content. Plaintext
+// and e2ee ciphertext stream straight from S3; content encrypted at rest has
+// to be decrypted as a whole first.
+func (s *Service) OpenEntryContent(ctx context.Context, userSub, journalID, entryDate string) (store.Journal, store.JournalEntry, io.ReadCloser, error) {
+	journal, entry, err := s.loadEntry(ctx, userSub, journalID, entryDate, RoleViewer)
+	if err != nil {
+		return store.Journal{}, store.JournalEntry{}, nil, err
+	}
+
+	body, _, err := s.s3.Open(ctx, entry.S3Key)
+	if err != nil {
+		return store.Journal{}, store.JournalEntry{}, nil, fmt.Errorf("failed to download from S3: %w", err)
+	}
+	if s.enc == nil || journal.E2EE {
+		return journal, entry, body, nil
+	}
+	defer body.Close()
+
+	blob, err := io.ReadAll(body)
+	if err != nil {
+		return store.Journal{}, store.JournalEntry{}, nil, fmt.Errorf("failed to download from S3: %w", err)
+	}
+	content, err := s.open(ctx, journal.UserSub, blob)
+	if err != nil {
+		return store.Journal{}, store.JournalEntry{}, nil, err
+	}
+	return journal, entry, io.NopCloser(bytes.NewReader(content)), nil
+}
+
+// errEntryMoved reports a conditional write to an entry whose commit is no
+// longer the one the write was based on
+var errEntryMoved = errors.New("conflict: entry changed since its base commit")
+
 // UpdateEntry updates an existing journal entry
-func (s *Service) UpdateEntry(ctx context.Context, userSub, journalID, entryDate, content string) (store.JournalEntry, error) {
+func (s *Service) UpdateEntry(ctx context.Context, userSub, journalID, entryDate, content, clientMetadata string) (store.JournalEntry, error) {
+	return s.updateEntry(ctx, userSub, journalID, entryDate, content, clientMetadata, "")
+}
+
+// updateEntry updates an entry, only if it is still at baseCommit unless
+// that is empty. The check is repeated in the database write, so a
+// concurrent update cannot slip in between; its content is put back in S3.
+func (s *Service) updateEntry(ctx context.Context, userSub, journalID, entryDate, content, clientMetadata, baseCommit string) (store.JournalEntry, error) {
 	// Validate date format
 	date, err := time.Parse("2006-01-02", entryDate)
 	if err != nil {
@@ -203,60 +423,102 @@ func (s *Service) UpdateEntry(ctx context.Context, userSub, journalID, entryDate
 		return store.JournalEntry{}, fmt.Errorf("entry not found: %w", err)
 	}
 
-	// Verify journal belongs to user
-	_, err = s.store.GetJournal(ctx, journalID, userSub)
+	// Verify the user may write to the journal
+	journal, err := s.authorize(ctx, journalID, userSub, RoleEditor)
 	if err != nil {
-		return store.JournalEntry{}, fmt.Errorf("journal not found: %w", err)
+		return store.JournalEntry{}, err
+	}
+	if baseCommit != "" && entry.GitCommitHash.String != baseCommit {
+		return store.JournalEntry{}, errEntryMoved
 	}
 
-	// Sanitize content
-	content = sanitizeMarkdown(content)
-
-	// Calculate word count
-	wordCount := countWords(content)
+	// Sanitize, count and encrypt content for storage
+	blob, facts, err := s.prepareContent(ctx, journal, content, clientMetadata)
+	if err != nil {
+		return store.JournalEntry{}, err
+	}
 
 	// Upload new version to S3 (overwrite)
-	if err := s.s3.Upload(ctx, entry.S3Key, []byte(content)); err != nil {
+	if err := s.s3.Upload(ctx, entry.S3Key, blob); err != nil {
 		return store.JournalEntry{}, fmt.Errorf("failed to upload to S3: %w", err)
 	}
 
-	// Commit to Git
-	commitHash, err := s.git.CommitFile(userSub, journalID, entryDate, content, fmt.Sprintf("Update entry for %s", entryDate))
+	// Commit to Git, folding quick successive edits into one commit
+	commitHash, amended, err := s.commitUpdate(ctx, journal, entry, userSub, entryDate, blob)
 	if err != nil {
 		return store.JournalEntry{}, fmt.Errorf("failed to commit to Git: %w", err)
 	}
 
 	// Update database
+	s.invalidateEntry(ctx, entry)
 	entry.GitCommitHash = sql.NullString{String: commitHash, Valid: true}
-	entry.WordCount = sql.NullInt32{Int32: int32(wordCount), Valid: true}
-	if err := s.store.UpdateJournalEntry(ctx, entry); err != nil {
-		return store.JournalEntry{}, fmt.Errorf("failed to update entry: %w", err)
+	facts.apply(&entry)
+	entry.ClientMetadata = sql.NullString{String: clientMetadata, Valid: clientMetadata != ""}
+	if baseCommit == "" {
+		if err := s.store.UpdateJournalEntry(ctx, entry); err != nil {
+			return store.JournalEntry{}, fmt.Errorf("failed to update entry: %w", err)
+		}
+	} else {
+		updated, err := s.store.UpdateJournalEntryIfCommit(ctx, entry, baseCommit)
+		if err != nil {
+			return store.JournalEntry{}, fmt.Errorf("failed to update entry: %w", err)
+		}
+		if !updated {
+			s.restoreEntryBlob(ctx, journal, entry)
+			return store.JournalEntry{}, errEntryMoved
+		}
 	}
 
-	// Save version to database
-	version := store.JournalVersion{
-		EntryID:       entry.ID,
-		CommitHash:    commitHash,
-		CommitMessage: sql.NullString{String: fmt.Sprintf("Update entry for %s", entryDate), Valid: true},
-		AuthorName:    sql.NullString{String: "LifeLogger System", Valid: true},
-		AuthorEmail:   sql.NullString{String: "system@lifelogger.life", Valid: true},
-		CreatedAt:     time.Now(),
+	// Save version to database; an amended commit keeps its version
+	if !amended {
+		version := store.JournalVersion{
+			EntryID:       entry.ID,
+			CommitHash:    commitHash,
+			CommitMessage: sql.NullString{String: fmt.Sprintf("Update entry for %s", entryDate), Valid: true},
+			AuthorName:    sql.NullString{String: userSub, Valid: true},
+			CreatedAt:     time.Now(),
+		}
+		_, err = s.store.CreateJournalVersion(ctx, version)
+		if err != nil {
+			// Log error but don't fail the operation
+			fmt.Printf("Warning: failed to save version: %v\n", err)
+		}
 	}
-	_, err = s.store.CreateJournalVersion(ctx, version)
-	if err != nil {
-		// Log error but don't fail the operation
-		fmt.Printf("Warning: failed to save version: %v\n", err)
+
+	s.invalidateStats(ctx, journalID)
+	s.updateLinks(ctx, userSub, journal, entry, content)
+
+	s.emit(ctx, events.EntryUpdated, journalID, userSub, entryEventData(entry))
+	if !amended {
+		s.emit(ctx, events.VersionCreated, journalID, userSub, entryEventData(entry))
 	}
 
 	return entry, nil
 }
 
+// restoreEntryBlob puts an entry's current version back in S3 after a
+// conditional update overwrote it and then lost. The losing commit stays in git
+// history.
+func (s *Service) restoreEntryBlob(ctx context.Context, journal store.Journal, entry store.JournalEntry) {
+	current, err := s.store.GetJournalEntry(ctx, entry.ID)
+	if err != nil {
+		// Deleted meanwhile, along with its object
+		return
+	}
+	blob, err := s.git.GetFileContent(journal.UserSub, journal.ID, current.EntryDate.Format("2006-01-02"), current.GitCommitHash.String)
+	if err == nil {
+		err = s.s3.Upload(ctx, current.S3Key, blob)
+	}
+	if err != nil {
+		fmt.Printf("Warning: failed to restore entry %s in S3: %v\n", entry.ID, err)
+	}
+}
+
 // ListEntries lists all entries for a journal
 func (s *Service) ListEntries(ctx context.Context, userSub, journalID string) ([]store.JournalEntry, error) {
-	// Verify journal belongs to user
-	_, err := s.store.GetJournal(ctx, journalID, userSub)
-	if err != nil {
-		return nil, fmt.Errorf("journal not found: %w", err)
+	// Verify the user is a member of the journal
+	if _, err := s.authorize(ctx, journalID, userSub, RoleViewer); err != nil {
+		return nil, err
 	}
 
 	return s.store.ListJournalEntries(ctx, journalID)
@@ -264,6 +526,12 @@ func (s *Service) ListEntries(ctx context.Context, userSub, journalID string) ([
 
 // DeleteEntry deletes a journal entry
 func (s *Service) DeleteEntry(ctx context.Context, userSub, journalID, entryDate string) error {
+	return s.deleteEntry(ctx, userSub, journalID, entryDate, "")
+}
+
+// deleteEntry deletes an entry, only if it is still at baseCommit unless
+// that is empty
+func (s *Service) deleteEntry(ctx context.Context, userSub, journalID, entryDate, baseCommit string) error {
 	// Validate date format
 	date, err := time.Parse("2006-01-02", entryDate)
 	if err != nil {
@@ -276,10 +544,35 @@ func (s *Service) DeleteEntry(ctx context.Context, userSub, journalID, entryDate
 		return fmt.Errorf("entry not found: %w", err)
 	}
 
-	// Verify journal belongs to user
-	_, err = s.store.GetJournal(ctx, journalID, userSub)
+	// Verify the user may write to the journal
+	if _, err := s.authorize(ctx, journalID, userSub, RoleEditor); err != nil {
+		return err
+	}
+	if baseCommit != "" && entry.GitCommitHash.String != baseCommit {
+		return errEntryMoved
+	}
+
+	// The attachment rows go with the entry, so list them first
+	attachments, err := s.store.ListEntryAttachments(ctx, entry.ID)
 	if err != nil {
-		return fmt.Errorf("journal not found: %w", err)
+		return fmt.Errorf("failed to list attachments: %w", err)
+	}
+
+	// Delete from database before S3, so a conditional delete that loses
+	// leaves the objects alone. Links made by the entry go with it; links to
+	// it are kept against its date and resolve again if the day is rewritten.
+	if baseCommit == "" {
+		if err := s.store.DeleteJournalEntry(ctx, entry.ID); err != nil {
+			return err
+		}
+	} else {
+		deleted, err := s.store.DeleteJournalEntryIfCommit(ctx, entry.ID, baseCommit)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return errEntryMoved
+		}
 	}
 
 	// Delete from S3
@@ -287,35 +580,225 @@ func (s *Service) DeleteEntry(ctx context.Context, userSub, journalID, entryDate
 		// Log error but continue
 		fmt.Printf("Warning: failed to delete S3 object: %v\n", err)
 	}
+	s.deleteAttachmentObjects(ctx, attachments)
+	s.invalidateEntry(ctx, entry)
+	s.invalidateStats(ctx, journalID)
 
-	// Delete from database
-	return s.store.DeleteJournalEntry(ctx, entry.ID)
+	s.emit(ctx, events.EntryDeleted, journalID, userSub, map[string]interface{}{
+		"entry_id":   entry.ID,
+		"entry_date": entry.EntryDate.Format("2006-01-02"),
+	})
+	return nil
 }
 
 // ListVersions lists all versions (commits) for an entry
 func (s *Service) ListVersions(ctx context.Context, userSub, journalID, entryDate string) ([]git.CommitInfo, error) {
-	// Verify journal belongs to user
-	_, err := s.store.GetJournal(ctx, journalID, userSub)
+	// Verify the user is a member of the journal
+	journal, err := s.authorize(ctx, journalID, userSub, RoleViewer)
 	if err != nil {
-		return nil, fmt.Errorf("journal not found: %w", err)
+		return nil, err
 	}
 
-	return s.git.ListCommits(userSub, journalID, entryDate)
+	return s.git.ListCommits(journal.UserSub, journalID, entryDate)
 }
 
 // GetVersion gets a specific version of an entry
 func (s *Service) GetVersion(ctx context.Context, userSub, journalID, entryDate, commitHash string) ([]byte, error) {
-	// Verify journal belongs to user
-	_, err := s.store.GetJournal(ctx, journalID, userSub)
+	// Verify the user is a member of the journal
+	journal, err := s.authorize(ctx, journalID, userSub, RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	blob, err := s.git.GetFileContent(journal.UserSub, journalID, entryDate, commitHash)
 	if err != nil {
-		return nil, fmt.Errorf("journal not found: %w", err)
+		return nil, err
 	}
 
-	return s.git.GetFileContent(userSub, journalID, entryDate, commitHash)
+	return s.presentContent(ctx, journal, blob)
+}
+
+// RotateEncryptionKeys rewraps all data keys under the active master key,
+// then gives every user a fresh data key and re-encrypts their current S3
+// blobs with it. Git history keeps the older key versions, which are retained.
+func (s *Service) RotateEncryptionKeys(ctx context.Context) error {
+	if s.enc == nil {
+		return fmt.Errorf("encryption is not configured")
+	}
+
+	rewrapped, err := s.enc.RewrapDataKeys(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Rewrapped %d data keys under the active master key\n", rewrapped)
+
+	owners, err := s.store.ListJournalOwners(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list journal owners: %w", err)
+	}
+
+	for _, userSub := range owners {
+		version, err := s.enc.RotateDataKey(ctx, userSub)
+		if err != nil {
+			return fmt.Errorf("failed to rotate data key for %s: %w", userSub, err)
+		}
+
+		entries, err := s.store.ListEntriesByOwner(ctx, userSub)
+		if err != nil {
+			return fmt.Errorf("failed to list entries for %s: %w", userSub, err)
+		}
+
+		for _, entry := range entries {
+			if err := s.reencryptObject(ctx, userSub, entry.S3Key); err != nil {
+				return err
+			}
+			s.invalidateEntry(ctx, entry)
+		}
+
+		// Drafts are sealed with the owner's key too, whoever wrote them
+		drafts, err := s.store.ListDraftsByOwner(ctx, userSub)
+		if err != nil {
+			return fmt.Errorf("failed to list drafts for %s: %w", userSub, err)
+		}
+		for _, draft := range drafts {
+			if err := s.reencryptObject(ctx, userSub, draft.S3Key); err != nil {
+				return err
+			}
+		}
+
+		// Attachments stored before they were encrypted at rest are sealed now
+		attachments, err := s.store.ListAttachmentsByOwner(ctx, userSub)
+		if err != nil {
+			return fmt.Errorf("failed to list attachments for %s: %w", userSub, err)
+		}
+		for _, attachment := range attachments {
+			if err := s.resealAttachment(ctx, userSub, attachment); err != nil {
+				return err
+			}
+		}
+
+		fmt.Printf("Re-encrypted %d entries, %d drafts and %d attachments for %s with data key v%d\n", len(entries), len(drafts), len(attachments), userSub, version)
+	}
+
+	return nil
+}
+
+// reencryptObject rewrites an object sealed with one of the user's data
+// keys under their active key
+func (s *Service) reencryptObject(ctx context.Context, userSub, key string) error {
+	blob, err := s.s3.Download(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", key, err)
+	}
+	content, err := s.enc.Decrypt(ctx, userSub, blob)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt %s: %w", key, err)
+	}
+	blob, err = s.enc.Encrypt(ctx, userSub, content)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt %s: %w", key, err)
+	}
+	if err := s.s3.Upload(ctx, key, blob); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	return nil
 }
 
 // Helper functions
 
+// loadEntry loads an entry by date after checking the user's role in the journal
+func (s *Service) loadEntry(ctx context.Context, userSub, journalID, entryDate, minRole string) (store.Journal, store.JournalEntry, error) {
+	date, err := time.Parse("2006-01-02", entryDate)
+	if err != nil {
+		return store.Journal{}, store.JournalEntry{}, fmt.Errorf("invalid date format: %w", err)
+	}
+
+	journal, err := s.authorize(ctx, journalID, userSub, minRole)
+	if err != nil {
+		return store.Journal{}, store.JournalEntry{}, err
+	}
+
+	entry, err := s.store.GetJournalEntryByDate(ctx, journalID, date)
+	if err != nil {
+		return store.Journal{}, store.JournalEntry{}, fmt.Errorf("entry not found: %w", err)
+	}
+
+	return journal, entry, nil
+}
+
+// prepareContent turns request content into the blob stored in S3 and Git and
+// the facts recorded in Postgres: word count, mood and tags. Ciphertext of
+// end-to-end encrypted journals is stored as sent; its facts come from the
+// client metadata.
+func (s *Service) prepareContent(ctx context.Context, journal store.Journal, content, clientMetadata string) ([]byte, entryFacts, error) {
+	var meta struct {
+		WordCount *int32   `json:"word_count"`
+		Mood      string   `json:"mood"`
+		Tags      []string `json:"tags"`
+	}
+	if clientMetadata != "" {
+		if err := json.Unmarshal([]byte(clientMetadata), &meta); err != nil {
+			return nil, entryFacts{}, fmt.Errorf("invalid client metadata: %w", err)
+		}
+	}
+
+	if journal.E2EE {
+		ciphertext, err := base64.StdEncoding.DecodeString(content)
+		if err != nil {
+			return nil, entryFacts{}, fmt.Errorf("invalid ciphertext: content must be base64 encoded: %w", err)
+		}
+		facts := entryFacts{Mood: normalizeMood(meta.Mood), Tags: normalizeTags(meta.Tags)}
+		if meta.WordCount != nil {
+			facts.WordCount = sql.NullInt32{Int32: *meta.WordCount, Valid: true}
+		}
+		return ciphertext, facts, nil
+	}
+
+	// Sanitize content
+	content = sanitizeMarkdown(content)
+
+	// Calculate word count and pick out mood and tags
+	facts := extractFacts(content)
+
+	// Encrypt content at rest
+	blob, err := s.seal(ctx, journal.UserSub, content)
+	if err != nil {
+		return nil, entryFacts{}, err
+	}
+	return blob, facts, nil
+}
+
+// presentContent reverses prepareContent: stored blobs are decrypted, and
+// ciphertext of end-to-end encrypted journals is returned base64 encoded
+func (s *Service) presentContent(ctx context.Context, journal store.Journal, blob []byte) ([]byte, error) {
+	if journal.E2EE {
+		encoded := make([]byte, base64.StdEncoding.EncodedLen(len(blob)))
+		base64.StdEncoding.Encode(encoded, blob)
+		return encoded, nil
+	}
+	return s.open(ctx, journal.UserSub, blob)
+}
+
+// seal encrypts content for storage when encryption at rest is enabled
+func (s *Service) seal(ctx context.Context, ownerSub, content string) ([]byte, error) {
+	if s.enc == nil {
+		return []byte(content), nil
+	}
+	blob, err := s.enc.Encrypt(ctx, ownerSub, []byte(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt content: %w", err)
+	}
+	return blob, nil
+}
+
+// open decrypts stored content; plaintext blobs pass through unchanged
+func (s *Service) open(ctx context.Context, ownerSub string, blob []byte) ([]byte, error) {
+	if s.enc == nil {
+		return blob, nil
+	}
+	return s.enc.Decrypt(ctx, ownerSub, blob)
+}
+
 func sanitizeMarkdown(content string) string {
 	// Basic sanitization - remove null bytes and normalize line endings
 	content = strings.ReplaceAll(content, "\x00", "")
@@ -0,0 +1,162 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+var (
+	// wikiLinkPattern matches [[2025-12-24]] and [[<journal id>/2025-12-24]]
+	wikiLinkPattern = regexp.MustCompile(`\[\[(?:([0-9a-fA-F-]{36})/)?(\d{4}-\d{2}-\d{2})\]\]`)
+
+	// codePattern matches fenced code blocks and inline code, where [[...]]
+	// is literal text rather than a link
+	codePattern = regexp.MustCompile("(?s)```.*?```|`[^`\n]*`")
+)
+
+// LinkGraph is the graph of links made from a journal's entries. Nodes are
+// days, identified as "<journal id>/<date>".
+type LinkGraph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+}
+
+type GraphNode struct {
+	ID        string
+	JournalID string
+	Date      string
+	EntryID   string // empty when no entry exists for the day yet
+}
+
+type GraphEdge struct {
+	Source string
+	Target string
+}
+
+// parseLinks returns the distinct days an entry links to. Links without a
+// journal refer to the entry's own journal.
+func parseLinks(content, journalID string) []store.LinkTarget {
+	content = codePattern.ReplaceAllString(content, "")
+
+	seen := make(map[string]bool)
+	var targets []store.LinkTarget
+	for _, match := range wikiLinkPattern.FindAllStringSubmatch(content, -1) {
+		date, err := time.Parse("2006-01-02", match[2])
+		if err != nil {
+			continue
+		}
+		target := store.LinkTarget{JournalID: journalID, Date: date}
+		if match[1] != "" {
+			target.JournalID = match[1]
+		}
+
+		key := graphNodeID(target.JournalID, date)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// updateLinks re-parses an entry's links after it was saved. Links into other
+// journals are only kept when the author is a member there. Failures are
+// logged rather than failing the save; links are rebuilt on the next edit.
+func (s *Service) updateLinks(ctx context.Context, userSub string, journal store.Journal, entry store.JournalEntry, content string) {
+	// The server cannot read end-to-end encrypted content
+	if journal.E2EE {
+		return
+	}
+
+	var targets []store.LinkTarget
+	for _, target := range parseLinks(sanitizeMarkdown(content), journal.ID) {
+		if target.JournalID == journal.ID && target.Date.Equal(entry.EntryDate) {
+			continue
+		}
+		if target.JournalID != journal.ID {
+			if _, err := s.authorize(ctx, target.JournalID, userSub, RoleViewer); err != nil {
+				continue
+			}
+		}
+		targets = append(targets, target)
+	}
+
+	if err := s.store.ReplaceEntryLinks(ctx, entry.ID, journal.ID, targets); err != nil {
+		fmt.Printf("Warning: failed to update links for entry %s: %v\n", entry.ID, err)
+	}
+}
+
+// Backlinks lists the entries linking to a day, from journals the user can read
+func (s *Service) Backlinks(ctx context.Context, userSub, journalID, entryDate string) ([]store.EntryLink, error) {
+	date, err := time.Parse("2006-01-02", entryDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date format: %w", err)
+	}
+
+	if _, err := s.authorize(ctx, journalID, userSub, RoleViewer); err != nil {
+		return nil, err
+	}
+
+	links, err := s.store.ListBacklinks(ctx, journalID, date, userSub)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backlinks: %w", err)
+	}
+	return links, nil
+}
+
+// LinkGraph returns every entry of a journal together with the days its
+// entries link to
+func (s *Service) LinkGraph(ctx context.Context, userSub, journalID string) (LinkGraph, error) {
+	if _, err := s.authorize(ctx, journalID, userSub, RoleViewer); err != nil {
+		return LinkGraph{}, err
+	}
+
+	entries, err := s.store.ListJournalEntries(ctx, journalID)
+	if err != nil {
+		return LinkGraph{}, fmt.Errorf("failed to list entries: %w", err)
+	}
+	links, err := s.store.ListJournalLinks(ctx, journalID, userSub)
+	if err != nil {
+		return LinkGraph{}, fmt.Errorf("failed to list links: %w", err)
+	}
+
+	var graph LinkGraph
+	nodes := make(map[string]bool)
+	addNode := func(journalID string, date time.Time, entryID string) string {
+		id := graphNodeID(journalID, date)
+		if !nodes[id] {
+			nodes[id] = true
+			graph.Nodes = append(graph.Nodes, GraphNode{
+				ID:        id,
+				JournalID: journalID,
+				Date:      date.Format("2006-01-02"),
+				EntryID:   entryID,
+			})
+		}
+		return id
+	}
+
+	for _, entry := range entries {
+		addNode(journalID, entry.EntryDate, entry.ID)
+	}
+	for _, link := range links {
+		source := addNode(link.SourceJournalID, link.SourceDate, link.SourceEntryID)
+		target := addNode(link.TargetJournalID, link.TargetDate, link.TargetEntryID.String)
+		graph.Edges = append(graph.Edges, GraphEdge{Source: source, Target: target})
+	}
+	return graph, nil
+}
+
+func graphNodeID(journalID string, date time.Time) string {
+	return journalID + "/" + date.Format("2006-01-02")
+}
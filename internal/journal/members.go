@@ -0,0 +1,195 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/telluriancorp/ll-journal/internal/events"
+	"github.com/telluriancorp/ll-journal/internal/git"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// Journal member roles, from most to least privileged
+const (
+	RoleOwner     = "owner"
+	RoleEditor    = "editor"
+	RoleCommenter = "commenter"
+	RoleViewer    = "viewer"
+)
+
+var roleRank = map[string]int{
+	RoleViewer:    1,
+	RoleCommenter: 2,
+	RoleEditor:    3,
+	RoleOwner:     4,
+}
+
+// authorize loads a journal the user is a member of and checks that their
+// role is at least minRole. Non-members get "not found" so journal IDs are
+// not disclosed.
+func (s *Service) authorize(ctx context.Context, journalID, userSub, minRole string) (store.Journal, error) {
+	journal, err := s.getJournal(ctx, journalID, userSub)
+	if err != nil {
+		return store.Journal{}, fmt.Errorf("journal not found: %w", err)
+	}
+	if roleRank[journal.Role] < roleRank[minRole] {
+		return store.Journal{}, fmt.Errorf("forbidden: requires %s role", minRole)
+	}
+	return journal, nil
+}
+
+// memberAuthor is the git author recorded for changes made by a member
+func memberAuthor(userSub string) git.Author {
+	return git.Author{Name: userSub}
+}
+
+// ListMembers lists the members of a journal
+func (s *Service) ListMembers(ctx context.Context, journalID, userSub string) ([]store.JournalMember, error) {
+	if _, err := s.authorize(ctx, journalID, userSub, RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.store.ListJournalMembers(ctx, journalID)
+}
+
+// RemoveMember revokes a member's access. Owners can remove anyone but
+// themselves; other members can only remove themselves.
+func (s *Service) RemoveMember(ctx context.Context, journalID, userSub, memberSub string) error {
+	minRole := RoleOwner
+	if memberSub == userSub {
+		minRole = RoleViewer
+	}
+	journal, err := s.authorize(ctx, journalID, userSub, minRole)
+	if err != nil {
+		return err
+	}
+	if memberSub == journal.UserSub {
+		return fmt.Errorf("invalid member: the owner cannot be removed")
+	}
+
+	removed, err := s.store.RemoveJournalMember(ctx, journalID, memberSub)
+	if err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+	if !removed {
+		return fmt.Errorf("member not found")
+	}
+	s.invalidateJournal(ctx, journalID, memberSub)
+	s.invalidateUserStats(ctx, memberSub)
+	s.closeCollabSessions(journalID, memberSub)
+
+	// Their drafts can no longer be published, or read
+	drafts, err := s.store.DeleteMemberDrafts(ctx, journalID, memberSub)
+	if err != nil {
+		fmt.Printf("Warning: failed to delete drafts of %s: %v\n", memberSub, err)
+	}
+	s.deleteDraftObjects(ctx, drafts)
+
+	// No longer a member, so named explicitly
+	s.emit(ctx, events.MemberRemoved, journalID, userSub, map[string]interface{}{
+		"user_sub": memberSub,
+	}, memberSub)
+	return nil
+}
+
+// InviteMember invites a user to a journal with the given role
+func (s *Service) InviteMember(ctx context.Context, journalID, userSub, inviteeSub, role string) (store.JournalInvite, error) {
+	journal, err := s.authorize(ctx, journalID, userSub, RoleOwner)
+	if err != nil {
+		return store.JournalInvite{}, err
+	}
+	if role == RoleOwner || roleRank[role] == 0 {
+		return store.JournalInvite{}, fmt.Errorf("invalid role %q: must be editor, commenter or viewer", role)
+	}
+	if inviteeSub == "" || inviteeSub == journal.UserSub {
+		return store.JournalInvite{}, fmt.Errorf("invalid invitee")
+	}
+	if journal.E2EE {
+		// Members would need the journal key, which only the owner's client holds
+		return store.JournalInvite{}, fmt.Errorf("invalid invite: end-to-end encrypted journals cannot be shared")
+	}
+
+	// Check if an invite is already pending
+	pending, err := s.store.ListJournalInvites(ctx, journalID)
+	if err != nil {
+		return store.JournalInvite{}, fmt.Errorf("failed to list invites: %w", err)
+	}
+	for _, invite := range pending {
+		if invite.InviteeSub == inviteeSub {
+			return store.JournalInvite{}, fmt.Errorf("invite for %s already exists", inviteeSub)
+		}
+	}
+
+	invite, err := s.store.CreateJournalInvite(ctx, store.JournalInvite{
+		JournalID:  journalID,
+		InviteeSub: inviteeSub,
+		Role:       role,
+		InvitedBy:  userSub,
+	})
+	if err != nil {
+		return store.JournalInvite{}, fmt.Errorf("failed to create invite: %w", err)
+	}
+	return invite, nil
+}
+
+// ListInvites lists a journal's pending invites
+func (s *Service) ListInvites(ctx context.Context, journalID, userSub string) ([]store.JournalInvite, error) {
+	if _, err := s.authorize(ctx, journalID, userSub, RoleOwner); err != nil {
+		return nil, err
+	}
+	return s.store.ListJournalInvites(ctx, journalID)
+}
+
+// ListPendingInvites lists the invites waiting for the user to accept
+func (s *Service) ListPendingInvites(ctx context.Context, userSub string) ([]store.JournalInvite, error) {
+	return s.store.ListPendingInvites(ctx, userSub)
+}
+
+// AcceptInvite makes the invitee a member with the invited role
+func (s *Service) AcceptInvite(ctx context.Context, inviteID, userSub string) (store.Journal, error) {
+	invite, err := s.store.GetJournalInvite(ctx, inviteID)
+	if err != nil || invite.InviteeSub != userSub {
+		return store.Journal{}, fmt.Errorf("invite not found")
+	}
+
+	accepted, err := s.store.AcceptJournalInvite(ctx, invite)
+	if err != nil {
+		return store.Journal{}, fmt.Errorf("failed to accept invite: %w", err)
+	}
+	if !accepted {
+		return store.Journal{}, fmt.Errorf("invite not found: no longer pending")
+	}
+
+	s.invalidateJournal(ctx, invite.JournalID, userSub)
+	s.invalidateUserStats(ctx, userSub)
+
+	s.emit(ctx, events.MemberAdded, invite.JournalID, userSub, map[string]interface{}{
+		"user_sub": userSub,
+		"role":     invite.Role,
+	})
+	return s.getJournal(ctx, invite.JournalID, userSub)
+}
+
+// RevokeInvite withdraws a pending invite
+func (s *Service) RevokeInvite(ctx context.Context, journalID, userSub, inviteID string) error {
+	if _, err := s.authorize(ctx, journalID, userSub, RoleOwner); err != nil {
+		return err
+	}
+
+	invite, err := s.store.GetJournalInvite(ctx, inviteID)
+	if err != nil || invite.JournalID != journalID {
+		return fmt.Errorf("invite not found")
+	}
+	revoked, err := s.store.RevokeJournalInvite(ctx, inviteID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke invite: %w", err)
+	}
+	if !revoked {
+		return fmt.Errorf("invite not found: no longer pending")
+	}
+	return nil
+}
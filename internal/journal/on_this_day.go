@@ -0,0 +1,125 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// excerptLength is the maximum number of characters in an entry excerpt
+const excerptLength = 280
+
+// Memory is an entry written on the same day in an earlier year
+type Memory struct {
+	Journal  store.Journal
+	Entry    store.JournalEntry
+	YearsAgo int
+	Excerpt  string // empty for end-to-end encrypted journals
+}
+
+// OnThisDay returns entries from previous years written on the same month and
+// day, across all the user's journals. Without a date, today is taken in the
+// given IANA time zone, or in the user's own time zone when none is given.
+func (s *Service) OnThisDay(ctx context.Context, userSub, entryDate, timeZone string) ([]Memory, error) {
+	date, err := s.resolveDay(ctx, userSub, entryDate, timeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.store.ListEntriesOnDay(ctx, userSub, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list entries: %w", err)
+	}
+
+	memories := make([]Memory, 0, len(entries))
+	journals := make(map[string]store.Journal)
+	for _, entry := range entries {
+		journal, ok := journals[entry.JournalID]
+		if !ok {
+			if journal, err = s.authorize(ctx, entry.JournalID, userSub, RoleViewer); err != nil {
+				continue
+			}
+			journals[entry.JournalID] = journal
+		}
+
+		memory := Memory{
+			Journal:  journal,
+			Entry:    entry,
+			YearsAgo: date.Year() - entry.EntryDate.Year(),
+		}
+		if !journal.E2EE {
+			// A missing excerpt should not hide the memory itself
+			if content, err := s.entryText(ctx, journal, entry); err == nil {
+				memory.Excerpt = excerpt(content, excerptLength)
+			} else {
+				fmt.Printf("Warning: failed to load excerpt for entry %s: %v\n", entry.ID, err)
+			}
+		}
+		memories = append(memories, memory)
+	}
+	return memories, nil
+}
+
+// resolveDay parses an entry date, defaulting to today in timeZone or, when
+// that is empty, in the user's time zone
+func (s *Service) resolveDay(ctx context.Context, userSub, entryDate, timeZone string) (time.Time, error) {
+	if entryDate == "" && timeZone == "" {
+		entryDate = DateToday
+	}
+	if entryDate != "" {
+		resolved, err := s.ResolveEntryDate(ctx, userSub, entryDate)
+		if err != nil {
+			return time.Time{}, err
+		}
+		date, err := time.Parse("2006-01-02", resolved)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date format: %w", err)
+		}
+		return date, nil
+	}
+
+	location, err := loadTimeZone(timeZone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	now := time.Now().In(location)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// entryText returns the plaintext of an entry of a server-readable journal
+func (s *Service) entryText(ctx context.Context, journal store.Journal, entry store.JournalEntry) (string, error) {
+	blob, err := s.readEntryBlob(ctx, entry)
+	if err != nil {
+		return "", fmt.Errorf("failed to download from S3: %w", err)
+	}
+	content, err := s.presentContent(ctx, journal, blob)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// excerpt collapses whitespace and cuts text at a word boundary so that it
+// is at most max characters long, including the trailing ellipsis
+func excerpt(text string, max int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+
+	runes := []rune(text)[:max-1]
+	cut := string(runes)
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " .,;:") + "…"
+}
@@ -0,0 +1,26 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/telluriancorp/ll-journal/internal/render"
+)
+
+// RenderContent renders entry or version content of a journal to sanitized
+// HTML. Attachment references are left for clients to resolve.
+func (s *Service) RenderContent(ctx context.Context, userSub, journalID string, content []byte) ([]byte, error) {
+	journal, err := s.authorize(ctx, journalID, userSub, RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	if journal.E2EE {
+		return nil, fmt.Errorf("not acceptable: end-to-end encrypted content can only be rendered by clients")
+	}
+	return render.HTML(content)
+}
@@ -0,0 +1,117 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// Relative dates accepted wherever an entry date is expected, resolved in
+// the user's time zone
+const (
+	DateToday     = "today"
+	DateYesterday = "yesterday"
+)
+
+func (s *Service) GetSettings(ctx context.Context, userSub string) (store.UserSettings, error) {
+	settings, err := s.store.GetUserSettings(ctx, userSub)
+	if err != nil {
+		return store.UserSettings{}, fmt.Errorf("failed to load settings: %w", err)
+	}
+	return settings, nil
+}
+
+// UpdateSettings saves a user's settings. The time zone must be an IANA name
+// such as "Europe/Lisbon".
+func (s *Service) UpdateSettings(ctx context.Context, userSub, timeZone string) (store.UserSettings, error) {
+	if _, err := loadTimeZone(timeZone); err != nil {
+		return store.UserSettings{}, err
+	}
+
+	settings, err := s.store.SaveUserSettings(ctx, store.UserSettings{UserSub: userSub, TimeZone: timeZone})
+	if err != nil {
+		return store.UserSettings{}, fmt.Errorf("failed to save settings: %w", err)
+	}
+	if s.cache != nil {
+		s.cache.Delete(ctx, timeZoneCacheKey(userSub))
+	}
+	return settings, nil
+}
+
+// ResolveEntryDate turns "today" and "yesterday" into dates in the user's
+// time zone. Other values are returned unchanged for the caller to validate.
+func (s *Service) ResolveEntryDate(ctx context.Context, userSub, entryDate string) (string, error) {
+	if entryDate != DateToday && entryDate != DateYesterday {
+		return entryDate, nil
+	}
+
+	today, err := s.userToday(ctx, userSub)
+	if err != nil {
+		return "", err
+	}
+	if entryDate == DateYesterday {
+		today = today.AddDate(0, 0, -1)
+	}
+	return today.Format("2006-01-02"), nil
+}
+
+// userToday returns the user's current date as a UTC midnight, the form
+// entry dates take
+func (s *Service) userToday(ctx context.Context, userSub string) (time.Time, error) {
+	location, err := s.userLocation(ctx, userSub)
+	if err != nil {
+		return time.Time{}, err
+	}
+	now := time.Now().In(location)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// userLocation loads the user's time zone, going through the cache
+func (s *Service) userLocation(ctx context.Context, userSub string) (*time.Location, error) {
+	if s.cache != nil {
+		if data, ok := s.cache.Get(ctx, timeZoneCacheKey(userSub)); ok {
+			if location, err := time.LoadLocation(string(data)); err == nil {
+				return location, nil
+			}
+		}
+	}
+
+	settings, err := s.GetSettings(ctx, userSub)
+	if err != nil {
+		return nil, err
+	}
+	location, err := time.LoadLocation(settings.TimeZone)
+	if err != nil {
+		// A zone removed from the tz database should not lock the user out
+		fmt.Printf("Warning: unknown time zone %q for user %s: %v\n", settings.TimeZone, userSub, err)
+		return time.UTC, nil
+	}
+	if s.cache != nil {
+		s.cache.Set(ctx, timeZoneCacheKey(userSub), []byte(settings.TimeZone), journalCacheTTL)
+	}
+	return location, nil
+}
+
+// loadTimeZone validates an IANA time zone name supplied by a client
+func loadTimeZone(name string) (*time.Location, error) {
+	// LoadLocation maps "" to UTC and "Local" to the server's zone
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("invalid time zone %q: expected an IANA name such as Europe/Lisbon", name)
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %w", name, err)
+	}
+	return location, nil
+}
+
+func timeZoneCacheKey(userSub string) string {
+	return fmt.Sprintf("timezone:%s", userSub)
+}
@@ -0,0 +1,304 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/telluriancorp/ll-journal/internal/render"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// Share link access outcomes recorded in the access log
+const (
+	ShareOutcomeOK              = "ok"
+	ShareOutcomePasswordMissing = "password_missing"
+	ShareOutcomePasswordInvalid = "password_invalid"
+	ShareOutcomeExpired         = "expired"
+	ShareOutcomeRevoked         = "revoked"
+)
+
+// ShareLinkRequest describes a share link to create. An empty CommitHash
+// shares whatever the entry currently says.
+type ShareLinkRequest struct {
+	CommitHash string
+	ExpiresAt  *time.Time
+	Password   string
+}
+
+// SharedEntry is what a share link shows
+type SharedEntry struct {
+	JournalTitle string
+	EntryDate    time.Time
+	CommitHash   string
+	Markdown     []byte
+	HTML         []byte
+}
+
+// ShareViewer identifies who opened a share link, for the access log
+type ShareViewer struct {
+	RemoteAddr string
+	UserAgent  string
+}
+
+// CreateShareLink creates a public link to an entry and returns the link and
+// its token. Only the token's hash is stored, so it cannot be shown again.
+func (s *Service) CreateShareLink(ctx context.Context, userSub, journalID, entryDate string, req ShareLinkRequest) (store.ShareLink, string, error) {
+	journal, entry, err := s.loadEntry(ctx, userSub, journalID, entryDate, RoleOwner)
+	if err != nil {
+		return store.ShareLink{}, "", err
+	}
+	if journal.E2EE {
+		return store.ShareLink{}, "", fmt.Errorf("invalid share: end-to-end encrypted entries cannot be shared")
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return store.ShareLink{}, "", fmt.Errorf("invalid share: expires_at must be in the future")
+	}
+	if req.CommitHash != "" {
+		if _, err := s.store.GetJournalVersion(ctx, entry.ID, req.CommitHash); err != nil {
+			return store.ShareLink{}, "", fmt.Errorf("version not found: %w", err)
+		}
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return store.ShareLink{}, "", err
+	}
+
+	link := store.ShareLink{
+		EntryID:    entry.ID,
+		JournalID:  journalID,
+		TokenHash:  hashShareToken(token),
+		CommitHash: sql.NullString{String: req.CommitHash, Valid: req.CommitHash != ""},
+		CreatedBy:  userSub,
+	}
+	if req.ExpiresAt != nil {
+		link.ExpiresAt = sql.NullTime{Time: *req.ExpiresAt, Valid: true}
+	}
+	if req.Password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return store.ShareLink{}, "", fmt.Errorf("invalid share password: %w", err)
+		}
+		link.PasswordHash = sql.NullString{String: string(hash), Valid: true}
+	}
+
+	link, err = s.store.CreateShareLink(ctx, link)
+	if err != nil {
+		return store.ShareLink{}, "", fmt.Errorf("failed to create share link: %w", err)
+	}
+	return link, token, nil
+}
+
+// ListShareLinks lists an entry's share links, including revoked ones
+func (s *Service) ListShareLinks(ctx context.Context, userSub, journalID, entryDate string) ([]store.ShareLink, error) {
+	_, entry, err := s.loadEntry(ctx, userSub, journalID, entryDate, RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.ListEntryShareLinks(ctx, entry.ID)
+}
+
+// RevokeShareLink stops a share link from working
+func (s *Service) RevokeShareLink(ctx context.Context, userSub, journalID, entryDate, shareID string) error {
+	_, entry, err := s.loadEntry(ctx, userSub, journalID, entryDate, RoleOwner)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := s.store.RevokeShareLink(ctx, entry.ID, shareID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke share link: %w", err)
+	}
+	if !revoked {
+		return fmt.Errorf("share link not found")
+	}
+	return nil
+}
+
+// ListShareAccess returns the most recent attempts to open a share link
+func (s *Service) ListShareAccess(ctx context.Context, userSub, journalID, entryDate, shareID string) ([]store.ShareAccess, error) {
+	links, err := s.ListShareLinks(ctx, userSub, journalID, entryDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		if link.ID == shareID {
+			return s.store.ListShareAccess(ctx, shareID)
+		}
+	}
+	return nil, fmt.Errorf("share link not found")
+}
+
+// OpenShareLink resolves a share token without any user. Unknown, revoked
+// and expired tokens all report "not found"; every attempt on a known token
+// is logged.
+func (s *Service) OpenShareLink(ctx context.Context, token, password string, viewer ShareViewer) (SharedEntry, error) {
+	link, err := s.store.GetShareLinkByTokenHash(ctx, hashShareToken(token))
+	if err != nil {
+		return SharedEntry{}, fmt.Errorf("share link not found")
+	}
+
+	outcome := ShareOutcomeOK
+	switch {
+	case link.RevokedAt.Valid:
+		outcome = ShareOutcomeRevoked
+	case link.ExpiresAt.Valid && time.Now().After(link.ExpiresAt.Time):
+		outcome = ShareOutcomeExpired
+	case link.PasswordHash.Valid && password == "":
+		outcome = ShareOutcomePasswordMissing
+	case link.PasswordHash.Valid && bcrypt.CompareHashAndPassword([]byte(link.PasswordHash.String), []byte(password)) != nil:
+		outcome = ShareOutcomePasswordInvalid
+	}
+	s.logShareAccess(ctx, link, outcome, viewer)
+
+	switch outcome {
+	case ShareOutcomeRevoked, ShareOutcomeExpired:
+		return SharedEntry{}, fmt.Errorf("share link not found: %s", outcome)
+	case ShareOutcomePasswordMissing:
+		return SharedEntry{}, fmt.Errorf("password required")
+	case ShareOutcomePasswordInvalid:
+		return SharedEntry{}, fmt.Errorf("password required: incorrect password")
+	}
+
+	journal, err := s.store.GetJournalByID(ctx, link.JournalID)
+	if err != nil {
+		return SharedEntry{}, fmt.Errorf("share link not found: %w", err)
+	}
+	entry, err := s.store.GetJournalEntry(ctx, link.EntryID)
+	if err != nil {
+		return SharedEntry{}, fmt.Errorf("share link not found: %w", err)
+	}
+
+	var blob []byte
+	commitHash := entry.GitCommitHash.String
+	if link.CommitHash.Valid {
+		commitHash = link.CommitHash.String
+		blob, err = s.git.GetFileContent(journal.UserSub, journal.ID, entry.EntryDate.Format("2006-01-02"), commitHash)
+	} else {
+		blob, err = s.readEntryBlob(ctx, entry)
+	}
+	if err != nil {
+		return SharedEntry{}, fmt.Errorf("failed to load shared entry: %w", err)
+	}
+
+	content, err := s.open(ctx, journal.UserSub, blob)
+	if err != nil {
+		return SharedEntry{}, err
+	}
+	content = s.resolveAttachmentLinks(ctx, link, token, entry, content)
+
+	html, err := render.HTML(content)
+	if err != nil {
+		return SharedEntry{}, err
+	}
+
+	return SharedEntry{
+		JournalTitle: journal.Title,
+		EntryDate:    entry.EntryDate,
+		CommitHash:   commitHash,
+		Markdown:     content,
+		HTML:         html,
+	}, nil
+}
+
+// resolveAttachmentLinks replaces attachment: references with URLs anonymous
+// viewers can load: presigned S3 URLs, or for attachments sealed at rest the
+// share link's own attachment route
+func (s *Service) resolveAttachmentLinks(ctx context.Context, link store.ShareLink, token string, entry store.JournalEntry, content []byte) []byte {
+	attachments, err := s.store.ListEntryAttachments(ctx, entry.ID)
+	if err != nil || len(attachments) == 0 {
+		return content
+	}
+
+	var replacements []string
+	for _, attachment := range attachments {
+		url := sharedAttachmentURL(link, token, attachment.ID)
+		if !attachment.Encrypted {
+			url, err = s.AttachmentURL(ctx, attachment)
+			if err != nil {
+				fmt.Printf("Warning: failed to presign attachment %s: %v\n", attachment.ID, err)
+				continue
+			}
+		}
+		replacements = append(replacements, "(attachment:"+attachment.ID+")", "("+url+")")
+	}
+	return []byte(strings.NewReplacer(replacements...).Replace(string(content)))
+}
+
+// OpenSharedAttachment returns an attachment of a shared entry with its
+// content, for attachments that cannot be presigned. Behind a password the
+// token is not enough; the URL must carry the signature the share page put
+// there. Attachment fetches are not logged, the page that links them is.
+func (s *Service) OpenSharedAttachment(ctx context.Context, token, attachmentID, signature string) (store.Attachment, []byte, error) {
+	link, err := s.store.GetShareLinkByTokenHash(ctx, hashShareToken(token))
+	if err != nil {
+		return store.Attachment{}, nil, fmt.Errorf("share link not found")
+	}
+	if link.RevokedAt.Valid || (link.ExpiresAt.Valid && time.Now().After(link.ExpiresAt.Time)) {
+		return store.Attachment{}, nil, fmt.Errorf("share link not found")
+	}
+	if link.PasswordHash.Valid && !hmac.Equal([]byte(signature), []byte(sharedAttachmentSignature(link, attachmentID))) {
+		return store.Attachment{}, nil, fmt.Errorf("attachment not found")
+	}
+
+	journal, err := s.store.GetJournalByID(ctx, link.JournalID)
+	if err != nil {
+		return store.Attachment{}, nil, fmt.Errorf("share link not found: %w", err)
+	}
+	attachment, err := s.store.GetAttachment(ctx, link.EntryID, attachmentID)
+	if err != nil {
+		return store.Attachment{}, nil, fmt.Errorf("attachment not found: %w", err)
+	}
+	content, err := s.readAttachment(ctx, journal.UserSub, attachment)
+	if err != nil {
+		return store.Attachment{}, nil, err
+	}
+	return attachment, content, nil
+}
+
+// sharedAttachmentURL is the share page's route for an attachment. Password
+// protected links add a signature keyed by the password hash, which only the
+// server holds, so the URL can only come from a page opened with the password.
+func sharedAttachmentURL(link store.ShareLink, token, attachmentID string) string {
+	url := "/share/" + token + "/attachments/" + attachmentID
+	if link.PasswordHash.Valid {
+		url += "?sig=" + sharedAttachmentSignature(link, attachmentID)
+	}
+	return url
+}
+
+func sharedAttachmentSignature(link store.ShareLink, attachmentID string) string {
+	mac := hmac.New(sha256.New, []byte(link.PasswordHash.String))
+	mac.Write([]byte(attachmentID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Service) logShareAccess(ctx context.Context, link store.ShareLink, outcome string, viewer ShareViewer) {
+	err := s.store.LogShareAccess(ctx, store.ShareAccess{
+		ShareLinkID: link.ID,
+		Outcome:     outcome,
+		RemoteAddr:  sql.NullString{String: viewer.RemoteAddr, Valid: viewer.RemoteAddr != ""},
+		UserAgent:   sql.NullString{String: viewer.UserAgent, Valid: viewer.UserAgent != ""},
+	})
+	if err != nil {
+		fmt.Printf("Warning: failed to log share access: %v\n", err)
+	}
+}
+
+func hashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
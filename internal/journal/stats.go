@@ -0,0 +1,126 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// statsCacheTTL bounds how stale statistics can be when an invalidation is
+// missed, e.g. for a write handled by another instance without a shared cache
+const statsCacheTTL = 10 * time.Minute
+
+// statsHeatmapDays is how far back the per-day series reaches
+const statsHeatmapDays = 365
+
+// JournalStats returns writing statistics for one journal
+func (s *Service) JournalStats(ctx context.Context, userSub, journalID string) (store.EntryStats, error) {
+	if _, err := s.authorize(ctx, journalID, userSub, RoleViewer); err != nil {
+		return store.EntryStats{}, err
+	}
+
+	return s.cachedStats(ctx, userSub, journalStatsCacheKey(journalID), func(since, today time.Time) (store.EntryStats, error) {
+		return s.store.GetJournalStats(ctx, journalID, since, today)
+	})
+}
+
+// UserStats returns writing statistics across every journal the user is a member of
+func (s *Service) UserStats(ctx context.Context, userSub string) (store.EntryStats, error) {
+	return s.cachedStats(ctx, userSub, userStatsCacheKey(userSub), func(since, today time.Time) (store.EntryStats, error) {
+		return s.store.GetUserStats(ctx, userSub, since, today)
+	})
+}
+
+// cachedStats computes statistics once per key and day, where the day is
+// today in the requesting user's time zone. Writes invalidate the key, so the
+// queries only run again after something changed.
+func (s *Service) cachedStats(ctx context.Context, userSub, key string, compute func(since, today time.Time) (store.EntryStats, error)) (store.EntryStats, error) {
+	today, err := s.userToday(ctx, userSub)
+	if err != nil {
+		return store.EntryStats{}, err
+	}
+	since := today.AddDate(0, 0, -statsHeatmapDays)
+
+	// The current streak depends on the date, so each day gets its own entry
+	key = key + ":" + today.Format("2006-01-02")
+	if s.cache != nil {
+		if data, ok := s.cache.Get(ctx, key); ok {
+			var stats store.EntryStats
+			if err := json.Unmarshal(data, &stats); err == nil {
+				return stats, nil
+			}
+		}
+	}
+
+	stats, err := compute(since, today)
+	if err != nil {
+		return store.EntryStats{}, fmt.Errorf("failed to compute statistics: %w", err)
+	}
+	if s.cache != nil {
+		if data, err := json.Marshal(stats); err == nil {
+			s.cache.Set(ctx, key, data, statsCacheTTL)
+		}
+	}
+	return stats, nil
+}
+
+// invalidateStats evicts current statistics for a journal and for each of its
+// members after an entry was written or removed
+func (s *Service) invalidateStats(ctx context.Context, journalID string) {
+	if s.cache == nil {
+		return
+	}
+	members, err := s.store.ListJournalMembers(ctx, journalID)
+	if err != nil {
+		fmt.Printf("Warning: failed to list members for cache invalidation: %v\n", err)
+		return
+	}
+
+	var keys []string
+	for _, day := range statsCacheDays() {
+		keys = append(keys, journalStatsCacheKey(journalID)+":"+day)
+		for _, member := range members {
+			keys = append(keys, userStatsCacheKey(member.UserSub)+":"+day)
+		}
+	}
+	s.cache.Delete(ctx, keys...)
+}
+
+// invalidateUserStats evicts a user's statistics after they joined or left a journal
+func (s *Service) invalidateUserStats(ctx context.Context, userSub string) {
+	if s.cache == nil {
+		return
+	}
+	var keys []string
+	for _, day := range statsCacheDays() {
+		keys = append(keys, userStatsCacheKey(userSub)+":"+day)
+	}
+	s.cache.Delete(ctx, keys...)
+}
+
+// statsCacheDays lists the dates statistics may currently be cached under.
+// Time zones put "today" anywhere from yesterday to tomorrow in UTC.
+func statsCacheDays() []string {
+	now := time.Now().UTC()
+	return []string{
+		now.AddDate(0, 0, -1).Format("2006-01-02"),
+		now.Format("2006-01-02"),
+		now.AddDate(0, 0, 1).Format("2006-01-02"),
+	}
+}
+
+func journalStatsCacheKey(journalID string) string {
+	return fmt.Sprintf("stats:journal:%s", journalID)
+}
+
+func userStatsCacheKey(userSub string) string {
+	return fmt.Sprintf("stats:user:%s", userSub)
+}
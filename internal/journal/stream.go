@@ -0,0 +1,119 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/telluriancorp/ll-journal/internal/events"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+const (
+	// EventReset tells a stream client that events it asked to resume from
+	// are no longer logged, so it has to sync from scratch
+	EventReset = "reset"
+
+	// eventLogRetention is how long events stay resumable
+	eventLogRetention = 7 * 24 * time.Hour
+
+	// streamBatchSize is how many events one read of the log returns
+	streamBatchSize = 100
+
+	// streamHeartbeat keeps idle streams from being closed by proxies
+	streamHeartbeat = 15 * time.Second
+)
+
+// WithEventLog logs events for change streams
+func WithEventLog(l *events.Log) Option {
+	return func(s *Service) {
+		s.eventLog = l
+		s.publishers = append(s.publishers, l)
+	}
+}
+
+// StreamEvents sends the user's journal events to send until ctx is
+// cancelled or send fails. Without lastEventID the stream starts with events
+// logged from now on; with it, it resumes after that event. send is called
+// once with no events when the stream is ready, and again as a heartbeat
+// while it is idle.
+func (s *Service) StreamEvents(ctx context.Context, userSub, lastEventID string, send func([]store.LoggedEvent) error) error {
+	if s.eventLog == nil {
+		return fmt.Errorf("event stream is not enabled")
+	}
+
+	var last int64
+	if lastEventID != "" {
+		var err error
+		if last, err = strconv.ParseInt(lastEventID, 10, 64); err != nil || last < 0 {
+			return fmt.Errorf("invalid Last-Event-ID: %q", lastEventID)
+		}
+	}
+
+	// Subscribe before reading the log so nothing logged in between is missed
+	wake, unsubscribe := s.eventLog.Subscribe()
+	defer unsubscribe()
+
+	oldest, newest, err := s.store.EventLogBounds(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read event log: %w", err)
+	}
+	switch {
+	case lastEventID == "":
+		last = newest
+		err = send(nil)
+	case last+1 < oldest || last > newest:
+		// The events after last were pruned, or come from another database
+		last = newest
+		err = send([]store.LoggedEvent{{Seq: newest, EventType: EventReset, Payload: "{}", CreatedAt: time.Now()}})
+	default:
+		err = send(nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		batch, err := s.store.ListEventLog(ctx, userSub, last, streamBatchSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to read event log: %w", err)
+		}
+		if len(batch) > 0 {
+			if err := send(batch); err != nil {
+				return err
+			}
+			last = batch[len(batch)-1].Seq
+			heartbeat.Reset(streamHeartbeat)
+			if len(batch) == streamBatchSize {
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-wake:
+		case <-heartbeat.C:
+			if err := send(nil); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// CleanupEventLog prunes events that are too old to resume from
+func (s *Service) CleanupEventLog(ctx context.Context) (int64, error) {
+	return s.store.DeleteEventLogBefore(ctx, time.Now().Add(-eventLogRetention))
+}
@@ -0,0 +1,253 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// Sync change kinds
+const (
+	SyncCreated = "created"
+	SyncUpdated = "updated"
+	SyncDeleted = "deleted"
+)
+
+// Sync push operations and their outcomes
+const (
+	SyncOpCreate = "create"
+	SyncOpUpdate = "update"
+	SyncOpDelete = "delete"
+
+	SyncApplied  = "applied"
+	SyncConflict = "conflict"
+	SyncError    = "error"
+)
+
+const (
+	// DefaultSyncLimit and MaxSyncLimit bound the changes in one sync page
+	DefaultSyncLimit = 100
+	MaxSyncLimit     = 500
+
+	// MaxSyncPush is the most changes one push may carry
+	MaxSyncPush = 100
+)
+
+// SyncItem is the current state of a changed journal or entry. Journal or
+// Entry is set unless the change is a deletion.
+type SyncItem struct {
+	Seq        int64
+	EntityType string
+	EntityID   string
+	JournalID  string
+	Change     string
+	Journal    *store.Journal
+	Entry      *store.JournalEntry
+	Content    []byte // entry content, when requested
+}
+
+// SyncPage is one page of changes. Cursor is passed as since to get the
+// next page, or to sync again later.
+type SyncPage struct {
+	Changes []SyncItem
+	Cursor  string
+	HasMore bool
+}
+
+// SyncChanges lists what changed in the user's journals after the since
+// cursor, one item per journal or entry with its current state. An empty
+// cursor lists everything the user can see.
+func (s *Service) SyncChanges(ctx context.Context, userSub, since string, limit int, includeContent bool) (SyncPage, error) {
+	var after int64
+	if since != "" {
+		var err error
+		if after, err = strconv.ParseInt(since, 10, 64); err != nil || after < 0 {
+			return SyncPage{}, fmt.Errorf("invalid cursor %q", since)
+		}
+	}
+	if limit <= 0 {
+		limit = DefaultSyncLimit
+	}
+	limit = min(limit, MaxSyncLimit)
+
+	changes, err := s.store.ListSyncChanges(ctx, userSub, after, limit+1)
+	if err != nil {
+		return SyncPage{}, fmt.Errorf("failed to list changes: %w", err)
+	}
+	page := SyncPage{Cursor: strconv.FormatInt(after, 10)}
+	if len(changes) > limit {
+		changes = changes[:limit]
+		page.HasMore = true
+	}
+	if len(changes) == 0 {
+		return page, nil
+	}
+
+	// Load current state in bulk. Anything missing was deleted, or the user
+	// lost access, after the change was logged; a tombstone follows.
+	journals, err := s.store.ListJournals(ctx, userSub)
+	if err != nil {
+		return SyncPage{}, fmt.Errorf("failed to list journals: %w", err)
+	}
+	journalsByID := make(map[string]store.Journal, len(journals))
+	for _, journal := range journals {
+		journalsByID[journal.ID] = journal
+	}
+
+	var entryIDs []string
+	for _, change := range changes {
+		if change.EntityType == store.SyncEntityEntry && !change.Deleted {
+			entryIDs = append(entryIDs, change.EntityID)
+		}
+	}
+	entriesByID := make(map[string]store.JournalEntry, len(entryIDs))
+	if len(entryIDs) > 0 {
+		entries, err := s.store.ListJournalEntriesByID(ctx, entryIDs)
+		if err != nil {
+			return SyncPage{}, fmt.Errorf("failed to load entries: %w", err)
+		}
+		for _, entry := range entries {
+			entriesByID[entry.ID] = entry
+		}
+	}
+
+	page.Changes = make([]SyncItem, len(changes))
+	for i, change := range changes {
+		item := SyncItem{
+			Seq:        change.Seq,
+			EntityType: change.EntityType,
+			EntityID:   change.EntityID,
+			JournalID:  change.JournalID,
+			Change:     SyncDeleted,
+		}
+		journal, member := journalsByID[change.JournalID]
+		entry, exists := entriesByID[change.EntityID]
+
+		switch {
+		case change.Deleted || !member:
+		case change.EntityType == store.SyncEntityJournal:
+			item.Journal = &journal
+		case exists:
+			item.Entry = &entry
+			if includeContent {
+				blob, err := s.readEntryBlob(ctx, entry)
+				if err != nil {
+					return SyncPage{}, fmt.Errorf("failed to download from S3: %w", err)
+				}
+				if item.Content, err = s.presentContent(ctx, journal, blob); err != nil {
+					return SyncPage{}, err
+				}
+			}
+		}
+		if item.Journal != nil || item.Entry != nil {
+			item.Change = SyncUpdated
+			if change.CreatedSeq > after {
+				item.Change = SyncCreated
+			}
+		}
+		page.Changes[i] = item
+	}
+	page.Cursor = strconv.FormatInt(changes[len(changes)-1].Seq, 10)
+	return page, nil
+}
+
+// SyncPush is an entry change a client made offline. Updates and deletes
+// name the commit they were made against, so that changes the client has not
+// seen are reported instead of overwritten.
+type SyncPush struct {
+	ClientRef      string
+	Op             string
+	JournalID      string
+	EntryDate      string
+	Content        string
+	ClientMetadata string
+	BaseCommitHash string
+}
+
+// SyncPushResult is the outcome of one pushed change. On a conflict, Entry
+// and Content are the server's current version for the client to merge.
+type SyncPushResult struct {
+	ClientRef string
+	Status    string
+	Error     string
+	Entry     *store.JournalEntry
+	Content   []byte
+}
+
+// PushChanges applies entry changes in order. Each succeeds or fails on its
+// own; the results line up with the changes.
+func (s *Service) PushChanges(ctx context.Context, userSub string, changes []SyncPush) ([]SyncPushResult, error) {
+	if len(changes) > MaxSyncPush {
+		return nil, fmt.Errorf("invalid request: at most %d changes per push", MaxSyncPush)
+	}
+
+	results := make([]SyncPushResult, len(changes))
+	for i, change := range changes {
+		result, err := s.pushChange(ctx, userSub, change)
+		if err != nil {
+			result = SyncPushResult{Status: SyncError, Error: err.Error()}
+		}
+		result.ClientRef = change.ClientRef
+		results[i] = result
+	}
+	return results, nil
+}
+
+func (s *Service) pushChange(ctx context.Context, userSub string, change SyncPush) (SyncPushResult, error) {
+	switch change.Op {
+	case SyncOpCreate:
+		entry, err := s.CreateEntry(ctx, userSub, change.JournalID, change.EntryDate, change.Content, "", change.ClientMetadata)
+		if err != nil {
+			if strings.Contains(err.Error(), "already exists") {
+				return s.syncConflict(ctx, userSub, change)
+			}
+			return SyncPushResult{}, err
+		}
+		return SyncPushResult{Status: SyncApplied, Entry: &entry}, nil
+
+	case SyncOpUpdate, SyncOpDelete:
+		if change.BaseCommitHash == "" {
+			return SyncPushResult{}, fmt.Errorf("invalid change: base_commit_hash is required")
+		}
+		// The base commit is checked again as the database row is written
+		if change.Op == SyncOpDelete {
+			err := s.deleteEntry(ctx, userSub, change.JournalID, change.EntryDate, change.BaseCommitHash)
+			if errors.Is(err, errEntryMoved) {
+				return s.syncConflict(ctx, userSub, change)
+			}
+			if err != nil {
+				return SyncPushResult{}, err
+			}
+			return SyncPushResult{Status: SyncApplied}, nil
+		}
+		entry, err := s.updateEntry(ctx, userSub, change.JournalID, change.EntryDate, change.Content, change.ClientMetadata, change.BaseCommitHash)
+		if errors.Is(err, errEntryMoved) {
+			return s.syncConflict(ctx, userSub, change)
+		}
+		if err != nil {
+			return SyncPushResult{}, err
+		}
+		return SyncPushResult{Status: SyncApplied, Entry: &entry}, nil
+
+	default:
+		return SyncPushResult{}, fmt.Errorf("invalid change: unknown op %q", change.Op)
+	}
+}
+
+// syncConflict reports the server's current version of a pushed entry
+func (s *Service) syncConflict(ctx context.Context, userSub string, change SyncPush) (SyncPushResult, error) {
+	entry, content, err := s.GetEntry(ctx, userSub, change.JournalID, change.EntryDate)
+	if err != nil {
+		return SyncPushResult{}, err
+	}
+	return SyncPushResult{Status: SyncConflict, Entry: &entry, Content: content}, nil
+}
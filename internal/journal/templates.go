@@ -0,0 +1,261 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// TemplateRequest is the editable part of an entry template. JournalID is
+// only used on creation; an empty value creates a personal template.
+type TemplateRequest struct {
+	JournalID string
+	Name      string
+	Body      string
+	Prompts   []string
+}
+
+func (req TemplateRequest) validate() error {
+	if strings.TrimSpace(req.Name) == "" {
+		return fmt.Errorf("invalid template: name is required")
+	}
+	if strings.TrimSpace(req.Body) == "" {
+		return fmt.Errorf("invalid template: body is required")
+	}
+	for _, prompt := range req.Prompts {
+		if strings.TrimSpace(prompt) == "" {
+			return fmt.Errorf("invalid template: prompts must not be empty")
+		}
+	}
+	return nil
+}
+
+// CreateTemplate creates a personal template, or a journal template when
+// req.JournalID is set. Journal templates are managed by editors.
+func (s *Service) CreateTemplate(ctx context.Context, userSub string, req TemplateRequest) (store.EntryTemplate, error) {
+	if err := req.validate(); err != nil {
+		return store.EntryTemplate{}, err
+	}
+
+	template := store.EntryTemplate{
+		UserSub: userSub,
+		Name:    req.Name,
+		Body:    req.Body,
+		Prompts: req.Prompts,
+	}
+	if req.JournalID != "" {
+		journal, err := s.authorize(ctx, req.JournalID, userSub, RoleEditor)
+		if err != nil {
+			return store.EntryTemplate{}, err
+		}
+		// The server cannot render into ciphertext
+		if journal.E2EE {
+			return store.EntryTemplate{}, fmt.Errorf("invalid template: templates are not available for end-to-end encrypted journals")
+		}
+		template.JournalID = sql.NullString{String: req.JournalID, Valid: true}
+	}
+
+	template, err := s.store.CreateTemplate(ctx, template)
+	if err != nil {
+		return store.EntryTemplate{}, fmt.Errorf("failed to create template: %w", err)
+	}
+	return template, nil
+}
+
+// ListTemplates lists the user's personal templates, or a journal's templates
+// when journalID is set
+func (s *Service) ListTemplates(ctx context.Context, userSub, journalID string) ([]store.EntryTemplate, error) {
+	if journalID == "" {
+		templates, err := s.store.ListUserTemplates(ctx, userSub)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list templates: %w", err)
+		}
+		return templates, nil
+	}
+
+	if _, err := s.authorize(ctx, journalID, userSub, RoleViewer); err != nil {
+		return nil, err
+	}
+	templates, err := s.store.ListJournalTemplates(ctx, journalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	return templates, nil
+}
+
+func (s *Service) GetTemplate(ctx context.Context, userSub, templateID string) (store.EntryTemplate, error) {
+	return s.loadTemplate(ctx, userSub, templateID, RoleViewer)
+}
+
+func (s *Service) UpdateTemplate(ctx context.Context, userSub, templateID string, req TemplateRequest) (store.EntryTemplate, error) {
+	if err := req.validate(); err != nil {
+		return store.EntryTemplate{}, err
+	}
+
+	template, err := s.loadTemplate(ctx, userSub, templateID, RoleEditor)
+	if err != nil {
+		return store.EntryTemplate{}, err
+	}
+
+	template.Name = req.Name
+	template.Body = req.Body
+	template.Prompts = req.Prompts
+	if err := s.store.UpdateTemplate(ctx, template); err != nil {
+		return store.EntryTemplate{}, fmt.Errorf("failed to update template: %w", err)
+	}
+	return s.store.GetTemplate(ctx, templateID)
+}
+
+// DeleteTemplate deletes a template; journals using it as their default fall
+// back to requiring content
+func (s *Service) DeleteTemplate(ctx context.Context, userSub, templateID string) error {
+	template, err := s.loadTemplate(ctx, userSub, templateID, RoleEditor)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.DeleteTemplate(ctx, template.ID); err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+	if template.JournalID.Valid {
+		s.invalidateMembers(ctx, template.JournalID.String)
+	}
+	return nil
+}
+
+// SetDefaultTemplate sets the template applied to new entries created without
+// content. An empty templateID clears it. Only the owner may change it, and
+// the template must belong to the journal or be one of the owner's own.
+func (s *Service) SetDefaultTemplate(ctx context.Context, userSub, journalID, templateID string) (store.Journal, error) {
+	journal, err := s.authorize(ctx, journalID, userSub, RoleOwner)
+	if err != nil {
+		return store.Journal{}, err
+	}
+
+	if templateID != "" {
+		if journal.E2EE {
+			return store.Journal{}, fmt.Errorf("invalid template: templates are not available for end-to-end encrypted journals")
+		}
+		template, err := s.loadTemplate(ctx, userSub, templateID, RoleViewer)
+		if err != nil {
+			return store.Journal{}, err
+		}
+		if template.JournalID.Valid && template.JournalID.String != journalID {
+			return store.Journal{}, fmt.Errorf("invalid template: template belongs to another journal")
+		}
+	}
+
+	if err := s.store.SetDefaultTemplate(ctx, journalID, sql.NullString{String: templateID, Valid: templateID != ""}); err != nil {
+		return store.Journal{}, fmt.Errorf("failed to set default template: %w", err)
+	}
+	s.invalidateMembers(ctx, journalID)
+	return s.getJournal(ctx, journalID, userSub)
+}
+
+// loadTemplate fetches a template the user may use: their own personal
+// templates, or templates of journals where they hold at least minRole
+func (s *Service) loadTemplate(ctx context.Context, userSub, templateID string, minRole string) (store.EntryTemplate, error) {
+	template, err := s.store.GetTemplate(ctx, templateID)
+	if err != nil {
+		return store.EntryTemplate{}, fmt.Errorf("template not found: %w", err)
+	}
+
+	if !template.JournalID.Valid {
+		if template.UserSub != userSub {
+			return store.EntryTemplate{}, fmt.Errorf("template not found")
+		}
+		return template, nil
+	}
+
+	if _, err := s.authorize(ctx, template.JournalID.String, userSub, minRole); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return store.EntryTemplate{}, fmt.Errorf("template not found")
+		}
+		return store.EntryTemplate{}, err
+	}
+	return template, nil
+}
+
+// initialContent resolves the content of a new entry created without any:
+// the requested template, or else the journal's default template
+func (s *Service) initialContent(ctx context.Context, userSub string, journal store.Journal, templateID string, date time.Time) (string, error) {
+	if templateID == "" {
+		// e2ee journals cannot have a usable default; their content is ciphertext
+		if !journal.DefaultTemplateID.Valid || journal.E2EE {
+			return "", fmt.Errorf("invalid entry: content is required")
+		}
+		template, err := s.defaultTemplate(ctx, journal)
+		if err != nil {
+			return "", err
+		}
+		return RenderTemplate(template, journal, date), nil
+	}
+	if journal.E2EE {
+		return "", fmt.Errorf("invalid template: templates are not available for end-to-end encrypted journals")
+	}
+
+	template, err := s.loadTemplate(ctx, userSub, templateID, RoleViewer)
+	if err != nil {
+		return "", err
+	}
+	if template.JournalID.Valid && template.JournalID.String != journal.ID {
+		return "", fmt.Errorf("invalid template: template belongs to another journal")
+	}
+	return RenderTemplate(template, journal, date), nil
+}
+
+// defaultTemplate fetches a journal's default template. The owner chose it,
+// so every member may use it, including when it is one of the owner's
+// personal templates.
+func (s *Service) defaultTemplate(ctx context.Context, journal store.Journal) (store.EntryTemplate, error) {
+	template, err := s.store.GetTemplate(ctx, journal.DefaultTemplateID.String)
+	if err != nil {
+		return store.EntryTemplate{}, fmt.Errorf("template not found: %w", err)
+	}
+	if template.JournalID.Valid && template.JournalID.String != journal.ID ||
+		!template.JournalID.Valid && template.UserSub != journal.UserSub {
+		return store.EntryTemplate{}, fmt.Errorf("template not found")
+	}
+	return template, nil
+}
+
+// RenderTemplate fills a template's placeholders for an entry date:
+//
+//	{{date}}       2025-12-24
+//	{{weekday}}    Wednesday
+//	{{long_date}}  December 24, 2025
+//	{{journal}}    the journal title
+//	{{prompt}}     one of the template's prompts, rotating daily
+//
+// Unknown placeholders are left as written.
+func RenderTemplate(template store.EntryTemplate, journal store.Journal, date time.Time) string {
+	replacements := []string{
+		"{{date}}", date.Format("2006-01-02"),
+		"{{weekday}}", date.Weekday().String(),
+		"{{long_date}}", date.Format("January 2, 2006"),
+		"{{journal}}", journal.Title,
+		"{{prompt}}", dailyPrompt(template.Prompts, date),
+	}
+	return strings.NewReplacer(replacements...).Replace(template.Body)
+}
+
+// dailyPrompt picks the prompt for a date so that consecutive days walk
+// through the list in order and the same date always gets the same prompt
+func dailyPrompt(prompts []string, date time.Time) string {
+	if len(prompts) == 0 {
+		return ""
+	}
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+	// Dates before 1970 give a negative day, and Go's % keeps the sign
+	n := int64(len(prompts))
+	return prompts[((day%n)+n)%n]
+}
@@ -0,0 +1,321 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/telluriancorp/ll-journal/internal/s3"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+const (
+	UploadKindEntry      = "entry"
+	UploadKindAttachment = "attachment"
+
+	// maxEntryUploadBytes limits Markdown (or ciphertext) uploaded directly to S3
+	maxEntryUploadBytes = 10 << 20
+
+	// uploadConfirmGrace is how long after its URL expires an upload may
+	// still be confirmed, so a PUT that started just before expiry can finish
+	// before cleanup deletes the object
+	uploadConfirmGrace = 15 * time.Minute
+)
+
+// UploadRequest describes a file the client wants to upload directly to S3
+type UploadRequest struct {
+	Kind        string
+	ContentType string
+	Filename    string
+	SizeBytes   int64
+}
+
+// UploadResult is what a confirmed upload produced
+type UploadResult struct {
+	Entry      *store.JournalEntry
+	Attachment *store.Attachment
+}
+
+// RequestUpload records a pending upload and returns a presigned PUT URL
+// constrained to the declared content type and size
+func (s *Service) RequestUpload(ctx context.Context, userSub, journalID, entryDate string, req UploadRequest) (store.PendingUpload, s3.PresignedRequest, error) {
+	date, err := time.Parse("2006-01-02", entryDate)
+	if err != nil {
+		return store.PendingUpload{}, s3.PresignedRequest{}, fmt.Errorf("invalid date format: %w", err)
+	}
+
+	journal, err := s.authorize(ctx, journalID, userSub, RoleEditor)
+	if err != nil {
+		return store.PendingUpload{}, s3.PresignedRequest{}, err
+	}
+
+	if req.SizeBytes <= 0 {
+		return store.PendingUpload{}, s3.PresignedRequest{}, fmt.Errorf("invalid upload: size_bytes is required")
+	}
+
+	token, err := randomToken(16)
+	if err != nil {
+		return store.PendingUpload{}, s3.PresignedRequest{}, err
+	}
+
+	upload := store.PendingUpload{
+		UserSub:     userSub,
+		JournalID:   journalID,
+		EntryDate:   date,
+		Kind:        req.Kind,
+		ContentType: req.ContentType,
+		SizeBytes:   req.SizeBytes,
+		ExpiresAt:   time.Now().Add(s.s3.PresignExpiry() + uploadConfirmGrace),
+	}
+
+	switch req.Kind {
+	case UploadKindEntry:
+		allowed := map[string]bool{"text/markdown": true, "text/plain": true}
+		if journal.E2EE {
+			allowed = map[string]bool{"application/octet-stream": true}
+		}
+		if !allowed[req.ContentType] {
+			return store.PendingUpload{}, s3.PresignedRequest{}, fmt.Errorf("invalid upload: content type %q not allowed for entries", req.ContentType)
+		}
+		if req.SizeBytes > maxEntryUploadBytes {
+			return store.PendingUpload{}, s3.PresignedRequest{}, fmt.Errorf("invalid upload: entries are limited to %d bytes", maxEntryUploadBytes)
+		}
+		upload.S3Key = s3.GenerateUploadKey(journal.UserSub, journalID, token)
+
+	case UploadKindAttachment:
+		// Staged like entries, but the entry must already exist to attach to
+		if _, err := s.store.GetJournalEntryByDate(ctx, journalID, date); err != nil {
+			return store.PendingUpload{}, s3.PresignedRequest{}, fmt.Errorf("entry not found: %w", err)
+		}
+		if req.ContentType == "" {
+			return store.PendingUpload{}, s3.PresignedRequest{}, fmt.Errorf("invalid upload: content_type is required")
+		}
+		if req.SizeBytes > s.attachmentMaxBytes {
+			return store.PendingUpload{}, s3.PresignedRequest{}, fmt.Errorf("invalid upload: attachments are limited to %d bytes", s.attachmentMaxBytes)
+		}
+		filename := cleanFilename(req.Filename)
+		upload.Filename = sql.NullString{String: filename, Valid: true}
+		upload.S3Key = s3.GenerateUploadKey(journal.UserSub, journalID, token)
+
+	default:
+		return store.PendingUpload{}, s3.PresignedRequest{}, fmt.Errorf("invalid upload: unknown kind %q", req.Kind)
+	}
+
+	presigned, err := s.s3.PresignPut(ctx, upload.S3Key, upload.ContentType, upload.SizeBytes)
+	if err != nil {
+		return store.PendingUpload{}, s3.PresignedRequest{}, fmt.Errorf("failed to presign upload: %w", err)
+	}
+
+	upload, err = s.store.CreatePendingUpload(ctx, upload)
+	if err != nil {
+		return store.PendingUpload{}, s3.PresignedRequest{}, fmt.Errorf("failed to save upload: %w", err)
+	}
+
+	return upload, presigned, nil
+}
+
+// ConfirmUpload verifies an uploaded object against what was requested and
+// only then commits it: entries go through the regular create/update path
+// into Git and Postgres, attachments are copied to their own key and
+// recorded with their checksum.
+func (s *Service) ConfirmUpload(ctx context.Context, userSub, journalID, entryDate, uploadID, clientMetadata string) (UploadResult, error) {
+	upload, err := s.store.GetPendingUpload(ctx, uploadID, userSub)
+	if err != nil {
+		return UploadResult{}, fmt.Errorf("upload not found: %w", err)
+	}
+	if upload.JournalID != journalID || upload.EntryDate.Format("2006-01-02") != entryDate {
+		return UploadResult{}, fmt.Errorf("upload not found for this entry")
+	}
+	if upload.ConfirmedAt.Valid {
+		return UploadResult{}, fmt.Errorf("upload already confirmed")
+	}
+	if time.Now().After(upload.ExpiresAt) {
+		return UploadResult{}, fmt.Errorf("invalid upload: expired at %s", upload.ExpiresAt.Format(time.RFC3339))
+	}
+
+	journal, err := s.authorize(ctx, journalID, userSub, RoleEditor)
+	if err != nil {
+		return UploadResult{}, err
+	}
+
+	// Verify the object before committing anything
+	info, err := s.s3.Head(ctx, upload.S3Key)
+	if err != nil {
+		return UploadResult{}, fmt.Errorf("invalid upload: object not found in S3: %w", err)
+	}
+	if info.Size != upload.SizeBytes {
+		return UploadResult{}, fmt.Errorf("invalid upload: size %d does not match declared %d", info.Size, upload.SizeBytes)
+	}
+	if info.ContentType != upload.ContentType {
+		return UploadResult{}, fmt.Errorf("invalid upload: content type %q does not match declared %q", info.ContentType, upload.ContentType)
+	}
+
+	// Claim the upload before committing anything, so a concurrent
+	// confirmation stops here. A claim that does not lead to a commit is
+	// released so the client can retry.
+	claimed, err := s.store.ConfirmPendingUpload(ctx, upload.ID)
+	if err != nil {
+		return UploadResult{}, fmt.Errorf("failed to confirm upload: %w", err)
+	}
+	if !claimed {
+		return UploadResult{}, fmt.Errorf("upload already confirmed")
+	}
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		if err := s.store.ReleasePendingUpload(context.WithoutCancel(ctx), upload.ID); err != nil {
+			fmt.Printf("Warning: failed to release upload %s: %v\n", upload.ID, err)
+		}
+	}()
+
+	switch upload.Kind {
+	case UploadKindEntry:
+		data, err := s.s3.Download(ctx, upload.S3Key)
+		if err != nil {
+			return UploadResult{}, fmt.Errorf("failed to download from S3: %w", err)
+		}
+		if int64(len(data)) != upload.SizeBytes {
+			return UploadResult{}, fmt.Errorf("invalid upload: size %d does not match declared %d", len(data), upload.SizeBytes)
+		}
+
+		content := string(data)
+		if journal.E2EE {
+			content = base64.StdEncoding.EncodeToString(data)
+		}
+
+		var entry store.JournalEntry
+		if _, err := s.store.GetJournalEntryByDate(ctx, journalID, upload.EntryDate); err == nil {
+			entry, err = s.UpdateEntry(ctx, userSub, journalID, entryDate, content, clientMetadata)
+			if err != nil {
+				return UploadResult{}, err
+			}
+		} else {
+			entry, err = s.CreateEntry(ctx, userSub, journalID, entryDate, content, "", clientMetadata)
+			if err != nil {
+				return UploadResult{}, err
+			}
+		}
+
+		committed = true
+
+		// The staged object has been copied into the entry's own key
+		if err := s.s3.Delete(ctx, upload.S3Key); err != nil {
+			fmt.Printf("Warning: failed to delete staged upload %s: %v\n", upload.S3Key, err)
+		}
+		return UploadResult{Entry: &entry}, nil
+
+	default:
+		entry, err := s.store.GetJournalEntryByDate(ctx, journalID, upload.EntryDate)
+		if err != nil {
+			return UploadResult{}, fmt.Errorf("entry not found: %w", err)
+		}
+
+		// The presigned URL keeps working until it expires, so the staged
+		// object is copied to a key of its own and the copy is what gets
+		// hashed, and sealed with encryption at rest
+		token, err := randomToken(8)
+		if err != nil {
+			return UploadResult{}, err
+		}
+		s3Key := s3.GenerateAttachmentKey(journal.UserSub, journalID, entryDate, token, upload.Filename.String)
+
+		staged, _, err := s.s3.Open(ctx, upload.S3Key)
+		if err != nil {
+			return UploadResult{}, fmt.Errorf("failed to download from S3: %w", err)
+		}
+		defer staged.Close()
+
+		sealed := s.sealsAttachments(journal)
+		body := s3.LimitReader(staged, upload.SizeBytes)
+		var result s3.StreamResult
+		if sealed {
+			result, err = s.uploadSealedAttachment(ctx, journal.UserSub, s3Key, body)
+		} else {
+			result, err = s.s3.UploadStream(ctx, s3Key, body, upload.ContentType)
+		}
+		if errors.Is(err, s3.ErrTooLarge) {
+			return UploadResult{}, fmt.Errorf("invalid upload: larger than declared %d bytes", upload.SizeBytes)
+		}
+		if err != nil {
+			return UploadResult{}, fmt.Errorf("failed to upload to S3: %w", err)
+		}
+		if result.Size != upload.SizeBytes {
+			s.deleteAttachmentObjects(ctx, []store.Attachment{{S3Key: s3Key}})
+			return UploadResult{}, fmt.Errorf("invalid upload: size %d does not match declared %d", result.Size, upload.SizeBytes)
+		}
+
+		attachment, err := s.store.CreateAttachment(ctx, store.Attachment{
+			EntryID:        entry.ID,
+			JournalID:      journalID,
+			S3Key:          s3Key,
+			Filename:       upload.Filename.String,
+			ContentType:    upload.ContentType,
+			SizeBytes:      result.Size,
+			ChecksumSHA256: result.SHA256,
+			Encrypted:      sealed,
+		})
+		if err != nil {
+			s.deleteAttachmentObjects(ctx, []store.Attachment{{S3Key: s3Key}})
+			return UploadResult{}, fmt.Errorf("failed to save attachment: %w", err)
+		}
+
+		committed = true
+
+		// Anything PUT to the staged key from now on is removed by cleanup
+		// once the upload expires
+		if err := s.s3.Delete(ctx, upload.S3Key); err != nil {
+			fmt.Printf("Warning: failed to delete staged upload %s: %v\n", upload.S3Key, err)
+		}
+		return UploadResult{Attachment: &attachment}, nil
+	}
+}
+
+// EntryDownloadURL returns a presigned URL for the stored entry object.
+// With encryption at rest the object is ciphertext, so this is only offered
+// for plaintext storage and for e2ee journals, whose clients decrypt.
+func (s *Service) EntryDownloadURL(ctx context.Context, userSub, journalID, entryDate string) (string, error) {
+	journal, entry, err := s.loadEntry(ctx, userSub, journalID, entryDate, RoleViewer)
+	if err != nil {
+		return "", err
+	}
+	if s.enc != nil && !journal.E2EE {
+		return "", fmt.Errorf("direct download unavailable: entry is encrypted at rest")
+	}
+
+	url, err := s.s3.PresignGet(ctx, entry.S3Key, entryDate+".md")
+	if err != nil {
+		return "", fmt.Errorf("failed to presign download: %w", err)
+	}
+	return url, nil
+}
+
+// CleanupExpiredUploads removes the records of expired uploads with their
+// staged objects: unconfirmed uploads, and anything PUT again to the staging
+// key of a confirmed one
+func (s *Service) CleanupExpiredUploads(ctx context.Context) (int, error) {
+	uploads, err := s.store.ListExpiredUploads(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired uploads: %w", err)
+	}
+
+	for _, upload := range uploads {
+		if err := s.s3.Delete(ctx, upload.S3Key); err != nil {
+			fmt.Printf("Warning: failed to delete expired upload %s: %v\n", upload.S3Key, err)
+			continue
+		}
+		if err := s.store.DeletePendingUpload(ctx, upload.ID); err != nil {
+			return 0, fmt.Errorf("failed to delete upload record: %w", err)
+		}
+	}
+	return len(uploads), nil
+}
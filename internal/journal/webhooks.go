@@ -0,0 +1,190 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package journal
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/telluriancorp/ll-journal/internal/events"
+	"github.com/telluriancorp/ll-journal/internal/store"
+	"github.com/telluriancorp/ll-journal/internal/webhooks"
+)
+
+// WithWebhooks publishes events to webhook subscriptions and enables
+// delivery replay
+func WithWebhooks(d *webhooks.Dispatcher) Option {
+	return func(s *Service) {
+		s.webhooks = d
+		s.publishers = append(s.publishers, d)
+	}
+}
+
+// WithAdmins lets the given users manage system-wide webhook subscriptions
+func WithAdmins(subs []string) Option {
+	return func(s *Service) {
+		s.admins = make(map[string]bool, len(subs))
+		for _, sub := range subs {
+			if sub = strings.TrimSpace(sub); sub != "" {
+				s.admins[sub] = true
+			}
+		}
+	}
+}
+
+// IsAdmin reports whether the user may manage system-wide subscriptions
+func (s *Service) IsAdmin(userSub string) bool {
+	return s.admins[userSub]
+}
+
+// WebhookRequest is the editable part of a webhook subscription. System is
+// only used on creation and requires an admin.
+type WebhookRequest struct {
+	URL        string
+	EventTypes []string
+	Active     *bool
+	System     bool
+}
+
+// validateWebhook checks the event types and that the URL is one deliveries may reach
+func (s *Service) validateWebhook(ctx context.Context, req WebhookRequest) error {
+	if s.webhooks == nil {
+		return fmt.Errorf("webhooks are not enabled")
+	}
+	if err := s.webhooks.CheckURL(ctx, req.URL); err != nil {
+		return err
+	}
+	for _, eventType := range req.EventTypes {
+		if !events.Known(eventType) {
+			return fmt.Errorf("invalid webhook: unknown event type %q", eventType)
+		}
+	}
+	return nil
+}
+
+// CreateWebhook creates a subscription to events from the user's journals,
+// or from every journal when req.System is set. The returned subscription
+// carries the signing secret.
+func (s *Service) CreateWebhook(ctx context.Context, userSub string, req WebhookRequest) (store.WebhookSubscription, error) {
+	if err := s.validateWebhook(ctx, req); err != nil {
+		return store.WebhookSubscription{}, err
+	}
+	if req.System && !s.IsAdmin(userSub) {
+		return store.WebhookSubscription{}, fmt.Errorf("forbidden: only admins can create system webhooks")
+	}
+
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		return store.WebhookSubscription{}, err
+	}
+
+	sub := store.WebhookSubscription{
+		UserSub:    sql.NullString{String: userSub, Valid: !req.System},
+		URL:        req.URL,
+		Secret:     secret,
+		EventTypes: req.EventTypes,
+		CreatedBy:  userSub,
+	}
+	if sub.EventTypes == nil {
+		sub.EventTypes = []string{}
+	}
+	sub, err = s.store.CreateWebhookSubscription(ctx, sub)
+	if err != nil {
+		return store.WebhookSubscription{}, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return sub, nil
+}
+
+// ListWebhooks lists the user's subscriptions; admins also see system ones
+func (s *Service) ListWebhooks(ctx context.Context, userSub string) ([]store.WebhookSubscription, error) {
+	subs, err := s.store.ListWebhookSubscriptions(ctx, userSub, s.IsAdmin(userSub))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	return subs, nil
+}
+
+func (s *Service) GetWebhook(ctx context.Context, userSub, webhookID string) (store.WebhookSubscription, error) {
+	return s.loadWebhook(ctx, userSub, webhookID)
+}
+
+func (s *Service) UpdateWebhook(ctx context.Context, userSub, webhookID string, req WebhookRequest) (store.WebhookSubscription, error) {
+	if err := s.validateWebhook(ctx, req); err != nil {
+		return store.WebhookSubscription{}, err
+	}
+
+	sub, err := s.loadWebhook(ctx, userSub, webhookID)
+	if err != nil {
+		return store.WebhookSubscription{}, err
+	}
+
+	sub.URL = req.URL
+	sub.EventTypes = req.EventTypes
+	if sub.EventTypes == nil {
+		sub.EventTypes = []string{}
+	}
+	if req.Active != nil {
+		sub.Active = *req.Active
+	}
+	if err := s.store.UpdateWebhookSubscription(ctx, sub); err != nil {
+		return store.WebhookSubscription{}, fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return s.store.GetWebhookSubscription(ctx, webhookID)
+}
+
+// DeleteWebhook deletes a subscription along with its delivery log
+func (s *Service) DeleteWebhook(ctx context.Context, userSub, webhookID string) error {
+	if _, err := s.loadWebhook(ctx, userSub, webhookID); err != nil {
+		return err
+	}
+	return s.store.DeleteWebhookSubscription(ctx, webhookID)
+}
+
+// ListWebhookDeliveries lists a subscription's most recent deliveries
+func (s *Service) ListWebhookDeliveries(ctx context.Context, userSub, webhookID string) ([]store.WebhookDelivery, error) {
+	if _, err := s.loadWebhook(ctx, userSub, webhookID); err != nil {
+		return nil, err
+	}
+	deliveries, err := s.store.ListWebhookDeliveries(ctx, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// ReplayWebhookDelivery sends a past delivery's event again as a new delivery
+func (s *Service) ReplayWebhookDelivery(ctx context.Context, userSub, webhookID, deliveryID string) (store.WebhookDelivery, error) {
+	if s.webhooks == nil {
+		return store.WebhookDelivery{}, fmt.Errorf("webhooks are not enabled")
+	}
+	if _, err := s.loadWebhook(ctx, userSub, webhookID); err != nil {
+		return store.WebhookDelivery{}, err
+	}
+	delivery, err := s.store.GetWebhookDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		return store.WebhookDelivery{}, fmt.Errorf("delivery not found: %w", err)
+	}
+	return s.webhooks.Replay(ctx, delivery)
+}
+
+// loadWebhook loads a subscription the user manages: their own, or a
+// system one if they are an admin
+func (s *Service) loadWebhook(ctx context.Context, userSub, webhookID string) (store.WebhookSubscription, error) {
+	sub, err := s.store.GetWebhookSubscription(ctx, webhookID)
+	if err != nil {
+		return store.WebhookSubscription{}, fmt.Errorf("webhook not found: %w", err)
+	}
+	if sub.UserSub.Valid && sub.UserSub.String == userSub {
+		return sub, nil
+	}
+	if !sub.UserSub.Valid && s.IsAdmin(userSub) {
+		return sub, nil
+	}
+	// Don't reveal other users' subscriptions
+	return store.WebhookSubscription{}, fmt.Errorf("webhook not found")
+}
@@ -0,0 +1,47 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+// Package render turns entry Markdown into sanitized HTML.
+package render
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+var (
+	markdown = goldmark.New(goldmark.WithExtensions(
+		// align attributes survive sanitizing; inline styles do not
+		extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+		extension.Strikethrough,
+		extension.Linkify,
+		extension.TaskList,
+	))
+
+	// policy allows what CommonMark and GFM produce, including task list
+	// checkboxes and attachment: references, and nothing that can run script
+	policy = func() *bluemonday.Policy {
+		p := bluemonday.UGCPolicy()
+		p.AllowURLSchemes("attachment")
+		p.AllowAttrs("type").Matching(bluemonday.SpaceSeparatedTokens).OnElements("input")
+		p.AllowAttrs("checked", "disabled").OnElements("input")
+		p.AllowAttrs("align").Matching(bluemonday.CellAlign).OnElements("th", "td")
+		return p
+	}()
+)
+
+// HTML renders CommonMark with GFM tables, strikethrough, autolinks and task
+// lists, then passes the result through an allowlist sanitizer
+func HTML(source []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := markdown.Convert(source, &buf); err != nil {
+		return nil, fmt.Errorf("failed to render markdown: %w", err)
+	}
+	return policy.SanitizeBytes(buf.Bytes()), nil
+}
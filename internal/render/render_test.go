@@ -0,0 +1,69 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTMLAttachmentReferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{name: "image", markdown: "![pic](attachment:0b5e)", want: `<img src="attachment:0b5e" alt="pic"`},
+		{name: "link", markdown: "[notes.pdf](attachment:0b5e)", want: `<a href="attachment:0b5e"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := HTML([]byte(tt.markdown))
+			if err != nil {
+				t.Fatalf("HTML() error = %v", err)
+			}
+			if !strings.Contains(string(html), tt.want) {
+				t.Errorf("HTML() = %s, want it to contain %s", html, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTMLSanitizes(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+		unwanted []string
+	}{
+		{name: "script tag", markdown: "hi <script>alert(1)</script>", want: "hi", unwanted: []string{"<script"}},
+		{name: "event handler", markdown: `<img src="x.png" onerror="alert(1)">`, unwanted: []string{"onerror"}},
+		{name: "javascript link", markdown: "[click](javascript:alert(1))", want: "click", unwanted: []string{"javascript:"}},
+		{name: "data image", markdown: "![x](data:text/html;base64,PHNjcmlwdD4=)", unwanted: []string{"data:"}},
+		{name: "iframe", markdown: `<iframe src="https://example.com"></iframe>`, unwanted: []string{"<iframe"}},
+		{name: "inline style", markdown: `<p style="position:fixed">x</p>`, unwanted: []string{"style="}},
+		{name: "task list", markdown: "- [x] done", want: `<input checked="" disabled="" type="checkbox"`},
+		{name: "table alignment", markdown: "| a |\n|:-:|\n| b |", want: `<td align="center">b</td>`},
+		{name: "strikethrough", markdown: "~~gone~~", want: "<del>gone</del>"},
+		{name: "https link", markdown: "[site](https://example.com)", want: `<a href="https://example.com" rel="nofollow">site</a>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := HTML([]byte(tt.markdown))
+			if err != nil {
+				t.Fatalf("HTML() error = %v", err)
+			}
+			if !strings.Contains(string(html), tt.want) {
+				t.Errorf("HTML() = %s, want it to contain %s", html, tt.want)
+			}
+			for _, unwanted := range tt.unwanted {
+				if strings.Contains(string(html), unwanted) {
+					t.Errorf("HTML() = %s, want no %s", html, unwanted)
+				}
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -18,16 +19,19 @@ import (
 )
 
 type Client struct {
-	client *s3.Client
-	bucket string
+	client        *s3.Client
+	presign       *s3.PresignClient
+	bucket        string
+	presignExpiry time.Duration
 }
 
 type Config struct {
-	Endpoint  string
-	Bucket    string
-	AccessKey string
-	SecretKey string
-	Region    string
+	Endpoint      string
+	Bucket        string
+	AccessKey     string
+	SecretKey     string
+	Region        string
+	PresignExpiry time.Duration // Lifetime of presigned URLs (default: 15 minutes)
 }
 
 func New(cfg Config) (*Client, error) {
@@ -43,9 +47,16 @@ func New(cfg Config) (*Client, error) {
 		}
 	})
 
+	presignExpiry := cfg.PresignExpiry
+	if presignExpiry <= 0 {
+		presignExpiry = 15 * time.Minute
+	}
+
 	return &Client{
-		client: client,
-		bucket: cfg.Bucket,
+		client:        client,
+		presign:       s3.NewPresignClient(client),
+		bucket:        cfg.Bucket,
+		presignExpiry: presignExpiry,
 	}, nil
 }
 
@@ -69,27 +80,111 @@ func loadAWSConfig(cfg Config) (aws.Config, error) {
 
 // Upload uploads content to S3 at the specified key
 func (c *Client) Upload(ctx context.Context, key string, content []byte) error {
+	return c.UploadObject(ctx, key, content, "text/markdown")
+}
+
+// UploadObject uploads content with an explicit content type. The Content-MD5
+// header lets S3 reject bodies corrupted in transit.
+func (c *Client) UploadObject(ctx context.Context, key string, content []byte, contentType string) error {
 	_, err := c.client.PutObject(ctx, &s3.PutObjectInput{
 		Bucket:      aws.String(c.bucket),
 		Key:         aws.String(key),
 		Body:        bytes.NewReader(content),
-		ContentType: aws.String("text/markdown"),
+		ContentType: aws.String(contentType),
+		ContentMD5:  aws.String(contentMD5(content)),
 	})
 	return err
 }
 
-// Download downloads content from S3 at the specified key
-func (c *Client) Download(ctx context.Context, key string) ([]byte, error) {
-	result, err := c.client.GetObject(ctx, &s3.GetObjectInput{
+// PresignGet returns a time-limited download URL for an object. When
+// filename is set, the response is served as an attachment with that name.
+func (c *Client) PresignGet(ctx context.Context, key, filename string) (string, error) {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+	}
+	if filename != "" {
+		input.ResponseContentDisposition = aws.String(fmt.Sprintf("inline; filename=%q", filename))
+	}
+
+	req, err := c.presign.PresignGetObject(ctx, input, s3.WithPresignExpires(c.presignExpiry))
+	if err != nil {
+		return "", err
+	}
+	return req.URL, nil
+}
+
+// PresignedRequest is a presigned URL together with the headers the client
+// must send unchanged for the signature to match
+type PresignedRequest struct {
+	URL     string
+	Method  string
+	Headers map[string]string
+	Expires time.Time
+}
+
+// PresignPut returns a time-limited upload URL. Content type and length are
+// part of the signature, so S3 rejects uploads that do not match them.
+func (c *Client) PresignPut(ctx context.Context, key, contentType string, size int64) (PresignedRequest, error) {
+	req, err := c.presign.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(c.bucket),
+		Key:           aws.String(key),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(size),
+	}, s3.WithPresignExpires(c.presignExpiry))
+	if err != nil {
+		return PresignedRequest{}, err
+	}
+
+	headers := map[string]string{
+		"Content-Type":   contentType,
+		"Content-Length": fmt.Sprintf("%d", size),
+	}
+	return PresignedRequest{
+		URL:     req.URL,
+		Method:  req.Method,
+		Headers: headers,
+		Expires: time.Now().Add(c.presignExpiry),
+	}, nil
+}
+
+// ObjectInfo describes a stored object
+type ObjectInfo struct {
+	Size        int64
+	ContentType string
+	ETag        string
+}
+
+// Head returns the size and content type of an object
+func (c *Client) Head(ctx context.Context, key string) (ObjectInfo, error) {
+	result, err := c.client.HeadObject(ctx, &s3.HeadObjectInput{
 		Bucket: aws.String(c.bucket),
 		Key:    aws.String(key),
 	})
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	return ObjectInfo{
+		Size:        aws.ToInt64(result.ContentLength),
+		ContentType: aws.ToString(result.ContentType),
+		ETag:        aws.ToString(result.ETag),
+	}, nil
+}
+
+// PresignExpiry returns the lifetime of presigned URLs
+func (c *Client) PresignExpiry() time.Duration {
+	return c.presignExpiry
+}
+
+// Download downloads content from S3 at the specified key
+func (c *Client) Download(ctx context.Context, key string) ([]byte, error) {
+	body, _, err := c.Open(ctx, key)
 	if err != nil {
 		return nil, err
 	}
-	defer result.Body.Close()
+	defer body.Close()
 
-	return io.ReadAll(result.Body)
+	return io.ReadAll(body)
 }
 
 // Delete deletes an object from S3
@@ -112,7 +207,7 @@ func (c *Client) Exists(ctx context.Context, key string) (bool, error) {
 		// For AWS S3 and MinIO, the error typically indicates the object doesn't exist
 		errStr := err.Error()
 		if errStr == "NoSuchKey" || errStr == "NotFound" ||
-		   errStr == "404 Not Found" || errStr == "NoSuchKey: The specified key does not exist." {
+			errStr == "404 Not Found" || errStr == "NoSuchKey: The specified key does not exist." {
 			return false, nil
 		}
 		return false, err
@@ -124,3 +219,23 @@ func (c *Client) Exists(ctx context.Context, key string) (bool, error) {
 func GenerateKey(userSub, journalID, entryDate string) string {
 	return fmt.Sprintf("%s/%s/%s.md", userSub, journalID, entryDate)
 }
+
+// GenerateUploadKey generates a staging S3 key for a direct upload
+func GenerateUploadKey(userSub, journalID, token string) string {
+	return fmt.Sprintf("%s/%s/uploads/%s", userSub, journalID, token)
+}
+
+// GenerateDraftKey generates an S3 key for a member's draft of an entry
+func GenerateDraftKey(userSub, journalID, entryDate, authorSub string) string {
+	return fmt.Sprintf("%s/%s/drafts/%s/%s.md", userSub, journalID, authorSub, entryDate)
+}
+
+// AttachmentPrefix returns the S3 prefix holding an entry's attachments
+func AttachmentPrefix(userSub, journalID, entryDate string) string {
+	return fmt.Sprintf("%s/%s/%s/attachments/", userSub, journalID, entryDate)
+}
+
+// GenerateAttachmentKey generates an S3 key for an entry attachment
+func GenerateAttachmentKey(userSub, journalID, entryDate, token, filename string) string {
+	return fmt.Sprintf("%s%s/%s", AttachmentPrefix(userSub, journalID, entryDate), token, filename)
+}
@@ -0,0 +1,191 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// PartSize is the size of each part of a multipart upload. Bodies smaller
+// than one part are sent with a single PutObject.
+const PartSize = 8 << 20
+
+// StreamResult describes an object written by UploadStream
+type StreamResult struct {
+	Size   int64
+	SHA256 string // hex-encoded checksum of the whole body
+}
+
+// UploadStream uploads a body of unknown length while holding at most one
+// part in memory. Each request carries a Content-MD5 header so S3 verifies
+// every part; the SHA-256 of the whole body is returned to the caller.
+func (c *Client) UploadStream(ctx context.Context, key string, body io.Reader, contentType string) (StreamResult, error) {
+	sum := sha256.New()
+	body = io.TeeReader(body, sum)
+
+	buf := make([]byte, PartSize)
+	n, err := io.ReadFull(body, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		// Fits in one part
+		if err := c.UploadObject(ctx, key, buf[:n], contentType); err != nil {
+			return StreamResult{}, err
+		}
+		return StreamResult{Size: int64(n), SHA256: hex.EncodeToString(sum.Sum(nil))}, nil
+	}
+	if err != nil {
+		return StreamResult{}, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	size, err := c.uploadMultipart(ctx, key, buf, body, contentType)
+	if err != nil {
+		return StreamResult{}, err
+	}
+	return StreamResult{Size: size, SHA256: hex.EncodeToString(sum.Sum(nil))}, nil
+}
+
+// uploadMultipart uploads the already-filled first part in buf followed by the
+// rest of body, aborting the upload on any failure
+func (c *Client) uploadMultipart(ctx context.Context, key string, buf []byte, body io.Reader, contentType string) (int64, error) {
+	created, err := c.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(c.bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to start multipart upload: %w", err)
+	}
+
+	abort := func(cause error) (int64, error) {
+		_, _ = c.client.AbortMultipartUpload(context.WithoutCancel(ctx), &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(c.bucket),
+			Key:      aws.String(key),
+			UploadId: created.UploadId,
+		})
+		return 0, cause
+	}
+
+	var (
+		parts []types.CompletedPart
+		size  int64
+		n     = len(buf)
+	)
+	for partNumber := int32(1); n > 0; partNumber++ {
+		part := buf[:n]
+		result, err := c.client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:        aws.String(c.bucket),
+			Key:           aws.String(key),
+			UploadId:      created.UploadId,
+			PartNumber:    aws.Int32(partNumber),
+			Body:          bytes.NewReader(part),
+			ContentLength: aws.Int64(int64(len(part))),
+			ContentMD5:    aws.String(contentMD5(part)),
+		})
+		if err != nil {
+			return abort(fmt.Errorf("failed to upload part %d: %w", partNumber, err))
+		}
+		parts = append(parts, types.CompletedPart{ETag: result.ETag, PartNumber: aws.Int32(partNumber)})
+		size += int64(len(part))
+
+		n, err = io.ReadFull(body, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return abort(fmt.Errorf("failed to read body: %w", err))
+		}
+	}
+
+	_, err = c.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(c.bucket),
+		Key:             aws.String(key),
+		UploadId:        created.UploadId,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		return abort(fmt.Errorf("failed to complete multipart upload: %w", err))
+	}
+	return size, nil
+}
+
+// Open returns a streaming reader for an object. Checksum mode is enabled so
+// the SDK validates the body against any checksum stored with the object.
+// The caller must close the reader.
+func (c *Client) Open(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	result, err := c.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket:       aws.String(c.bucket),
+		Key:          aws.String(key),
+		ChecksumMode: types.ChecksumModeEnabled,
+	})
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	return result.Body, ObjectInfo{
+		Size:        aws.ToInt64(result.ContentLength),
+		ContentType: aws.ToString(result.ContentType),
+		ETag:        aws.ToString(result.ETag),
+	}, nil
+}
+
+// DownloadTo streams an object into w and returns the number of bytes copied
+func (c *Client) DownloadTo(ctx context.Context, key string, w io.Writer) (int64, error) {
+	body, info, err := c.Open(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	n, err := io.Copy(w, body)
+	if err != nil {
+		return n, err
+	}
+	if info.Size > 0 && n != info.Size {
+		return n, fmt.Errorf("short read: got %d of %d bytes", n, info.Size)
+	}
+	return n, nil
+}
+
+// ErrTooLarge is returned by LimitReader once a body exceeds its limit
+var ErrTooLarge = errors.New("body exceeds size limit")
+
+// LimitReader fails with ErrTooLarge instead of silently truncating a body
+// larger than limit bytes
+func LimitReader(r io.Reader, limit int64) io.Reader {
+	return &limitedReader{r: r, remaining: limit}
+}
+
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, ErrTooLarge
+	}
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, ErrTooLarge
+	}
+	return n, err
+}
+
+func contentMD5(content []byte) string {
+	sum := md5.Sum(content)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
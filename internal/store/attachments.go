@@ -0,0 +1,111 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type Attachment struct {
+	ID             string
+	EntryID        string
+	JournalID      string
+	S3Key          string
+	Filename       string
+	ContentType    string
+	SizeBytes      int64
+	ChecksumSHA256 string
+	Encrypted      bool
+	CreatedAt      time.Time
+}
+
+const attachmentColumns = `id, entry_id, journal_id, s3_key, filename, content_type, size_bytes, checksum_sha256, encrypted, created_at`
+
+func scanAttachment(row scanner) (Attachment, error) {
+	var a Attachment
+	err := row.Scan(
+		&a.ID, &a.EntryID, &a.JournalID, &a.S3Key, &a.Filename,
+		&a.ContentType, &a.SizeBytes, &a.ChecksumSHA256, &a.Encrypted, &a.CreatedAt)
+	return a, err
+}
+
+func scanAttachments(rows *sql.Rows) ([]Attachment, error) {
+	defer rows.Close()
+
+	var attachments []Attachment
+	for rows.Next() {
+		a, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, a)
+	}
+	return attachments, rows.Err()
+}
+
+// Attachment operations
+
+func (s *Store) CreateAttachment(ctx context.Context, a Attachment) (Attachment, error) {
+	return scanAttachment(s.db.QueryRowContext(ctx, `
+		INSERT INTO entry_attachments (entry_id, journal_id, s3_key, filename, content_type, size_bytes, checksum_sha256, encrypted)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING `+attachmentColumns,
+		a.EntryID, a.JournalID, a.S3Key, a.Filename, a.ContentType, a.SizeBytes, a.ChecksumSHA256, a.Encrypted))
+}
+
+func (s *Store) GetAttachment(ctx context.Context, entryID, id string) (Attachment, error) {
+	return scanAttachment(s.db.QueryRowContext(ctx, `
+		SELECT `+attachmentColumns+`
+		FROM entry_attachments
+		WHERE entry_id = $1 AND id = $2`,
+		entryID, id))
+}
+
+func (s *Store) ListEntryAttachments(ctx context.Context, entryID string) ([]Attachment, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+attachmentColumns+`
+		FROM entry_attachments
+		WHERE entry_id = $1
+		ORDER BY created_at`,
+		entryID)
+	if err != nil {
+		return nil, err
+	}
+	return scanAttachments(rows)
+}
+
+func (s *Store) ListJournalAttachments(ctx context.Context, journalID string) ([]Attachment, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+attachmentColumns+`
+		FROM entry_attachments
+		WHERE journal_id = $1
+		ORDER BY created_at`,
+		journalID)
+	if err != nil {
+		return nil, err
+	}
+	return scanAttachments(rows)
+}
+
+// MarkAttachmentEncrypted records that an attachment's object is sealed
+func (s *Store) MarkAttachmentEncrypted(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE entry_attachments
+		SET encrypted = TRUE
+		WHERE id = $1`,
+		id)
+	return err
+}
+
+func (s *Store) DeleteAttachment(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM entry_attachments
+		WHERE id = $1`,
+		id)
+	return err
+}
@@ -0,0 +1,38 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// RecordNonce records a request nonce until expiresAt and reports whether it
+// was new. An expired record of the same nonce is replaced.
+func (s *Store) RecordNonce(ctx context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO auth_nonces (nonce, expires_at)
+		VALUES ($1, $2)
+		ON CONFLICT (nonce) DO UPDATE SET expires_at = EXCLUDED.expires_at
+		WHERE auth_nonces.expires_at < NOW()`,
+		nonce, expiresAt)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n == 1, err
+}
+
+// DeleteExpiredNonces removes nonces that can no longer be replayed
+func (s *Store) DeleteExpiredNonces(ctx context.Context) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM auth_nonces
+		WHERE expires_at < NOW()`)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
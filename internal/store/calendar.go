@@ -0,0 +1,57 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// CalendarDay summarises the entry written on one day
+type CalendarDay struct {
+	Date         time.Time
+	EntryID      string
+	WordCount    sql.NullInt32
+	Mood         sql.NullString
+	Tags         []string
+	VersionCount int64
+}
+
+// Calendar operations
+
+// ListCalendarDays summarises a journal's entries dated from start up to but
+// not including end, counting each entry's versions in the same query
+func (s *Store) ListCalendarDays(ctx context.Context, journalID string, start, end time.Time) ([]CalendarDay, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT journal_entries.entry_date, journal_entries.id, journal_entries.word_count,
+			journal_entries.mood, journal_entries.tags, COUNT(journal_versions.id)
+		FROM journal_entries
+		LEFT JOIN journal_versions ON journal_versions.entry_id = journal_entries.id
+		WHERE journal_entries.journal_id = $1
+			AND journal_entries.entry_date >= $2 AND journal_entries.entry_date < $3
+		GROUP BY journal_entries.id
+		ORDER BY journal_entries.entry_date`,
+		journalID, start.Format("2006-01-02"), end.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var days []CalendarDay
+	for rows.Next() {
+		var day CalendarDay
+		if err := rows.Scan(
+			&day.Date, &day.EntryID, &day.WordCount, &day.Mood, pq.Array(&day.Tags),
+			&day.VersionCount); err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+	return days, rows.Err()
+}
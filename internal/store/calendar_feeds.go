@@ -0,0 +1,98 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// CalendarFeed is a secret URL serving a journal as an ICS calendar
+type CalendarFeed struct {
+	ID             string
+	JournalID      string
+	UserSub        string
+	TokenHash      string
+	LastAccessedAt sql.NullTime
+	RevokedAt      sql.NullTime
+	CreatedAt      time.Time
+}
+
+const calendarFeedColumns = `id, journal_id, user_sub, token_hash, last_accessed_at, revoked_at, created_at`
+
+func scanCalendarFeed(row scanner) (CalendarFeed, error) {
+	var feed CalendarFeed
+	err := row.Scan(
+		&feed.ID, &feed.JournalID, &feed.UserSub, &feed.TokenHash,
+		&feed.LastAccessedAt, &feed.RevokedAt, &feed.CreatedAt)
+	return feed, err
+}
+
+// Calendar feed operations
+
+func (s *Store) CreateCalendarFeed(ctx context.Context, feed CalendarFeed) (CalendarFeed, error) {
+	return scanCalendarFeed(s.db.QueryRowContext(ctx, `
+		INSERT INTO calendar_feeds (journal_id, user_sub, token_hash)
+		VALUES ($1, $2, $3)
+		RETURNING `+calendarFeedColumns,
+		feed.JournalID, feed.UserSub, feed.TokenHash))
+}
+
+func (s *Store) GetCalendarFeedByTokenHash(ctx context.Context, tokenHash string) (CalendarFeed, error) {
+	return scanCalendarFeed(s.db.QueryRowContext(ctx, `
+		SELECT `+calendarFeedColumns+`
+		FROM calendar_feeds
+		WHERE token_hash = $1`,
+		tokenHash))
+}
+
+// ListCalendarFeeds lists a member's feeds for a journal, including revoked ones
+func (s *Store) ListCalendarFeeds(ctx context.Context, journalID, userSub string) ([]CalendarFeed, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+calendarFeedColumns+`
+		FROM calendar_feeds
+		WHERE journal_id = $1 AND user_sub = $2
+		ORDER BY created_at DESC`,
+		journalID, userSub)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var feeds []CalendarFeed
+	for rows.Next() {
+		feed, err := scanCalendarFeed(rows)
+		if err != nil {
+			return nil, err
+		}
+		feeds = append(feeds, feed)
+	}
+	return feeds, rows.Err()
+}
+
+// RevokeCalendarFeed revokes a member's feed and reports whether one was revoked
+func (s *Store) RevokeCalendarFeed(ctx context.Context, journalID, userSub, id string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE calendar_feeds
+		SET revoked_at = NOW()
+		WHERE id = $1 AND journal_id = $2 AND user_sub = $3 AND revoked_at IS NULL`,
+		id, journalID, userSub)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+func (s *Store) TouchCalendarFeed(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE calendar_feeds
+		SET last_accessed_at = NOW()
+		WHERE id = $1`,
+		id)
+	return err
+}
@@ -0,0 +1,218 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type DataKey struct {
+	ID          string
+	UserSub     string
+	Version     int
+	WrappedKey  []byte
+	MasterKeyID string
+	Active      bool
+	CreatedAt   time.Time
+	RetiredAt   sql.NullTime
+}
+
+// Data key operations
+
+// dataKeyLockClass namespaces the per-user advisory locks that serialize
+// data key creation
+const dataKeyLockClass = 0x4c4c444b // "LLDK"
+
+// CreateDataKey saves a new active data key for a user, retiring the
+// previous one. Creations for the same user are serialized, so versions
+// never collide.
+func (s *Store) CreateDataKey(ctx context.Context, key DataKey) (DataKey, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return DataKey{}, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1, hashtext($2))`, dataKeyLockClass, key.UserSub); err != nil {
+		return DataKey{}, err
+	}
+
+	// Retire the previous active key; it stays available to decrypt old blobs
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE user_data_keys
+		SET active = FALSE, retired_at = NOW()
+		WHERE user_sub = $1 AND active`,
+		key.UserSub); err != nil {
+		return DataKey{}, err
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO user_data_keys (user_sub, version, wrapped_key, master_key_id, active)
+		VALUES ($1, (SELECT COALESCE(MAX(version), 0) + 1 FROM user_data_keys WHERE user_sub = $1), $2, $3, TRUE)
+		RETURNING id, version, created_at`,
+		key.UserSub, key.WrappedKey, key.MasterKeyID).Scan(&key.ID, &key.Version, &key.CreatedAt)
+	if err != nil {
+		return DataKey{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return DataKey{}, err
+	}
+	key.Active = true
+	return key, nil
+}
+
+// CreateFirstDataKey saves a user's first data key. It reports false if the
+// user already has an active key, such as one created concurrently, which
+// the caller should load instead.
+func (s *Store) CreateFirstDataKey(ctx context.Context, key DataKey) (DataKey, bool, error) {
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO user_data_keys (user_sub, version, wrapped_key, master_key_id, active)
+		VALUES ($1, (SELECT COALESCE(MAX(version), 0) + 1 FROM user_data_keys WHERE user_sub = $1), $2, $3, TRUE)
+		ON CONFLICT DO NOTHING
+		RETURNING id, version, created_at`,
+		key.UserSub, key.WrappedKey, key.MasterKeyID).Scan(&key.ID, &key.Version, &key.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return DataKey{}, false, nil
+	}
+	if err != nil {
+		return DataKey{}, false, err
+	}
+	key.Active = true
+	return key, true, nil
+}
+
+func (s *Store) GetActiveDataKey(ctx context.Context, userSub string) (DataKey, error) {
+	var key DataKey
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id, user_sub, version, wrapped_key, master_key_id, active, created_at, retired_at
+		FROM user_data_keys
+		WHERE user_sub = $1 AND active`,
+		userSub).Scan(
+		&key.ID, &key.UserSub, &key.Version, &key.WrappedKey, &key.MasterKeyID,
+		&key.Active, &key.CreatedAt, &key.RetiredAt)
+	if err != nil {
+		return DataKey{}, err
+	}
+	return key, nil
+}
+
+func (s *Store) GetDataKey(ctx context.Context, userSub string, version int) (DataKey, error) {
+	var key DataKey
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id, user_sub, version, wrapped_key, master_key_id, active, created_at, retired_at
+		FROM user_data_keys
+		WHERE user_sub = $1 AND version = $2`,
+		userSub, version).Scan(
+		&key.ID, &key.UserSub, &key.Version, &key.WrappedKey, &key.MasterKeyID,
+		&key.Active, &key.CreatedAt, &key.RetiredAt)
+	if err != nil {
+		return DataKey{}, err
+	}
+	return key, nil
+}
+
+func (s *Store) ListDataKeys(ctx context.Context) ([]DataKey, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, user_sub, version, wrapped_key, master_key_id, active, created_at, retired_at
+		FROM user_data_keys
+		ORDER BY user_sub, version`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []DataKey
+	for rows.Next() {
+		var key DataKey
+		if err := rows.Scan(
+			&key.ID, &key.UserSub, &key.Version, &key.WrappedKey, &key.MasterKeyID,
+			&key.Active, &key.CreatedAt, &key.RetiredAt); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// RewrapDataKey replaces the wrapped form of a data key after a master key rotation
+func (s *Store) RewrapDataKey(ctx context.Context, id string, wrappedKey []byte, masterKeyID string) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE user_data_keys
+		SET wrapped_key = $1, master_key_id = $2
+		WHERE id = $3`,
+		wrappedKey, masterKeyID, id)
+	return err
+}
+
+// ListEntriesByOwner lists every entry in journals owned by a user, except
+// end-to-end encrypted journals whose content the server never decrypts
+func (s *Store) ListEntriesByOwner(ctx context.Context, userSub string) ([]JournalEntry, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+entryColumns+`
+		FROM journal_entries
+		WHERE journal_id IN (SELECT id FROM journals WHERE user_sub = $1 AND NOT e2ee)
+		ORDER BY journal_id, entry_date`,
+		userSub)
+	if err != nil {
+		return nil, err
+	}
+	return scanEntries(rows)
+}
+
+// ListDraftsByOwner lists every draft in the user's journals that is
+// encrypted at rest, whoever wrote it
+func (s *Store) ListDraftsByOwner(ctx context.Context, userSub string) ([]EntryDraft, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+draftColumns+`
+		FROM entry_drafts
+		WHERE journal_id IN (SELECT id FROM journals WHERE user_sub = $1 AND NOT e2ee)
+		ORDER BY journal_id, entry_date`,
+		userSub)
+	if err != nil {
+		return nil, err
+	}
+	return scanDrafts(rows)
+}
+
+// ListAttachmentsByOwner lists every attachment in the user's journals that
+// is, or should be, encrypted at rest
+func (s *Store) ListAttachmentsByOwner(ctx context.Context, userSub string) ([]Attachment, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+attachmentColumns+`
+		FROM entry_attachments
+		WHERE journal_id IN (SELECT id FROM journals WHERE user_sub = $1 AND NOT e2ee)
+		ORDER BY journal_id, created_at`,
+		userSub)
+	if err != nil {
+		return nil, err
+	}
+	return scanAttachments(rows)
+}
+
+// ListJournalOwners lists every distinct user that owns at least one journal
+func (s *Store) ListJournalOwners(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT DISTINCT user_sub
+		FROM journals
+		ORDER BY user_sub`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var owners []string
+	for rows.Next() {
+		var userSub string
+		if err := rows.Scan(&userSub); err != nil {
+			return nil, err
+		}
+		owners = append(owners, userSub)
+	}
+	return owners, rows.Err()
+}
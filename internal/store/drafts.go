@@ -0,0 +1,126 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// EntryDraft is a member's unpublished work on a day's entry. BaseCommitHash
+// is the entry's commit when the draft was started, NULL if there was no entry.
+type EntryDraft struct {
+	ID             string
+	JournalID      string
+	EntryDate      time.Time
+	UserSub        string
+	S3Key          string
+	BaseCommitHash sql.NullString
+	ClientMetadata sql.NullString
+	WordCount      sql.NullInt32
+	Saves          int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+const draftColumns = `id, journal_id, entry_date, user_sub, s3_key, base_commit_hash, client_metadata, word_count,
+	saves, created_at, updated_at`
+
+func scanDraft(row scanner) (EntryDraft, error) {
+	var d EntryDraft
+	err := row.Scan(
+		&d.ID, &d.JournalID, &d.EntryDate, &d.UserSub, &d.S3Key, &d.BaseCommitHash, &d.ClientMetadata,
+		&d.WordCount, &d.Saves, &d.CreatedAt, &d.UpdatedAt)
+	return d, err
+}
+
+func scanDrafts(rows *sql.Rows) ([]EntryDraft, error) {
+	defer rows.Close()
+
+	var drafts []EntryDraft
+	for rows.Next() {
+		d, err := scanDraft(rows)
+		if err != nil {
+			return nil, err
+		}
+		drafts = append(drafts, d)
+	}
+	return drafts, rows.Err()
+}
+
+// Entry draft operations
+
+// SaveEntryDraft creates a member's draft for a day or records another save
+// of it. The base commit is kept from the first save.
+func (s *Store) SaveEntryDraft(ctx context.Context, draft EntryDraft) (EntryDraft, error) {
+	return scanDraft(s.db.QueryRowContext(ctx, `
+		INSERT INTO entry_drafts (journal_id, entry_date, user_sub, s3_key, base_commit_hash, client_metadata, word_count)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (journal_id, entry_date, user_sub) DO UPDATE
+		SET s3_key = EXCLUDED.s3_key, client_metadata = EXCLUDED.client_metadata,
+			word_count = EXCLUDED.word_count, saves = entry_drafts.saves + 1, updated_at = NOW()
+		RETURNING `+draftColumns,
+		draft.JournalID, draft.EntryDate, draft.UserSub, draft.S3Key, draft.BaseCommitHash,
+		draft.ClientMetadata, draft.WordCount))
+}
+
+func (s *Store) GetEntryDraft(ctx context.Context, journalID string, entryDate time.Time, userSub string) (EntryDraft, error) {
+	return scanDraft(s.db.QueryRowContext(ctx, `
+		SELECT `+draftColumns+`
+		FROM entry_drafts
+		WHERE journal_id = $1 AND entry_date = $2 AND user_sub = $3`,
+		journalID, entryDate, userSub))
+}
+
+// ListEntryDrafts lists a member's drafts in a journal, most recently saved first
+func (s *Store) ListEntryDrafts(ctx context.Context, journalID, userSub string) ([]EntryDraft, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+draftColumns+`
+		FROM entry_drafts
+		WHERE journal_id = $1 AND user_sub = $2
+		ORDER BY updated_at DESC`,
+		journalID, userSub)
+	if err != nil {
+		return nil, err
+	}
+	return scanDrafts(rows)
+}
+
+// ListJournalDrafts lists every member's drafts in a journal
+func (s *Store) ListJournalDrafts(ctx context.Context, journalID string) ([]EntryDraft, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+draftColumns+`
+		FROM entry_drafts
+		WHERE journal_id = $1`,
+		journalID)
+	if err != nil {
+		return nil, err
+	}
+	return scanDrafts(rows)
+}
+
+func (s *Store) DeleteEntryDraft(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM entry_drafts
+		WHERE id = $1`,
+		id)
+	return err
+}
+
+// DeleteMemberDrafts deletes a member's drafts in a journal and returns them,
+// so that their content can be removed from S3
+func (s *Store) DeleteMemberDrafts(ctx context.Context, journalID, userSub string) ([]EntryDraft, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		DELETE FROM entry_drafts
+		WHERE journal_id = $1 AND user_sub = $2
+		RETURNING `+draftColumns,
+		journalID, userSub)
+	if err != nil {
+		return nil, err
+	}
+	return scanDrafts(rows)
+}
@@ -0,0 +1,109 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package store
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// eventLogLock serializes appends so that events commit in seq order and a
+// reader never moves past a seq that is still being written
+const eventLogLock = 0x4c4c4a45 // "LLJE"
+
+type LoggedEvent struct {
+	Seq       int64
+	EventID   string
+	EventType string
+	JournalID string
+	Actor     string
+	Payload   string
+	CreatedAt time.Time
+}
+
+const loggedEventColumns = `seq, event_id, event_type, journal_id, actor, payload, created_at`
+
+// AppendEventLog logs an event for the journal's members plus any extra
+// audience, and notifies channel with its seq once it is committed
+func (s *Store) AppendEventLog(ctx context.Context, event LoggedEvent, audience []string, channel string) (int64, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, eventLogLock); err != nil {
+		return 0, err
+	}
+
+	var seq int64
+	if err := tx.QueryRowContext(ctx, `
+		INSERT INTO event_log (event_id, event_type, journal_id, actor, payload, user_subs)
+		VALUES ($1, $2, $3, $4, $5, ARRAY(
+			SELECT user_sub FROM journal_members WHERE journal_id = $3
+			UNION
+			SELECT unnest($6::TEXT[])
+		))
+		RETURNING seq`,
+		event.EventID, event.EventType, event.JournalID, event.Actor, event.Payload, pq.Array(audience)).Scan(&seq); err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_notify($1, $2)`, channel, strconv.FormatInt(seq, 10)); err != nil {
+		return 0, err
+	}
+	return seq, tx.Commit()
+}
+
+// ListEventLog lists up to limit events for the user after seq, oldest first
+func (s *Store) ListEventLog(ctx context.Context, userSub string, afterSeq int64, limit int) ([]LoggedEvent, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+loggedEventColumns+`
+		FROM event_log
+		WHERE seq > $1 AND user_subs @> ARRAY[$2::TEXT]
+		ORDER BY seq
+		LIMIT $3`,
+		afterSeq, userSub, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []LoggedEvent
+	for rows.Next() {
+		var event LoggedEvent
+		if err := rows.Scan(&event.Seq, &event.EventID, &event.EventType, &event.JournalID,
+			&event.Actor, &event.Payload, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// EventLogBounds returns the oldest and newest seq still logged, both zero
+// when the log is empty
+func (s *Store) EventLogBounds(ctx context.Context) (oldest, newest int64, err error) {
+	err = s.db.QueryRowContext(ctx, `
+		SELECT COALESCE(MIN(seq), 0), COALESCE(MAX(seq), 0)
+		FROM event_log`).Scan(&oldest, &newest)
+	return oldest, newest, err
+}
+
+// DeleteEventLogBefore removes events logged before t
+func (s *Store) DeleteEventLogBefore(ctx context.Context, t time.Time) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM event_log
+		WHERE created_at < $1`,
+		t)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
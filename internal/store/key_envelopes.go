@@ -0,0 +1,76 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// JournalKeyEnvelope is the client-wrapped key of an end-to-end encrypted journal
+type JournalKeyEnvelope struct {
+	JournalID  string
+	Algorithm  string
+	KeyID      sql.NullString
+	WrappedKey string
+	Params     sql.NullString
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Key envelope operations
+
+// CreateE2EEJournal creates an end-to-end encrypted journal together with its key envelope
+func (s *Store) CreateE2EEJournal(ctx context.Context, journal Journal, envelope JournalKeyEnvelope) (Journal, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Journal{}, err
+	}
+	defer tx.Rollback()
+
+	journal.E2EE = true
+	if journal.ID, err = insertJournal(ctx, tx, journal); err != nil {
+		return Journal{}, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO journal_key_envelopes (journal_id, algorithm, key_id, wrapped_key, params)
+		VALUES ($1, $2, $3, $4, $5)`,
+		journal.ID, envelope.Algorithm, envelope.KeyID, envelope.WrappedKey, envelope.Params); err != nil {
+		return Journal{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Journal{}, err
+	}
+	return s.GetJournal(ctx, journal.ID, journal.UserSub)
+}
+
+func (s *Store) GetKeyEnvelope(ctx context.Context, journalID string) (JournalKeyEnvelope, error) {
+	var envelope JournalKeyEnvelope
+	err := s.db.QueryRowContext(ctx, `
+		SELECT journal_id, algorithm, key_id, wrapped_key, params, created_at, updated_at
+		FROM journal_key_envelopes
+		WHERE journal_id = $1`,
+		journalID).Scan(
+		&envelope.JournalID, &envelope.Algorithm, &envelope.KeyID, &envelope.WrappedKey,
+		&envelope.Params, &envelope.CreatedAt, &envelope.UpdatedAt)
+	if err != nil {
+		return JournalKeyEnvelope{}, err
+	}
+	return envelope, nil
+}
+
+// UpdateKeyEnvelope replaces the wrapped key, e.g. after the client changes its passphrase
+func (s *Store) UpdateKeyEnvelope(ctx context.Context, envelope JournalKeyEnvelope) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE journal_key_envelopes
+		SET algorithm = $1, key_id = $2, wrapped_key = $3, params = $4, updated_at = NOW()
+		WHERE journal_id = $5`,
+		envelope.Algorithm, envelope.KeyID, envelope.WrappedKey, envelope.Params, envelope.JournalID)
+	return err
+}
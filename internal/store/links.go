@@ -0,0 +1,131 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// EntryLink is a [[date]] reference from one entry to another day
+type EntryLink struct {
+	SourceEntryID   string
+	SourceJournalID string
+	SourceDate      time.Time
+	TargetJournalID string
+	TargetDate      time.Time
+	TargetEntryID   sql.NullString // unset while no entry exists for the target date
+	CreatedAt       time.Time
+}
+
+// LinkTarget is a day referenced from an entry
+type LinkTarget struct {
+	JournalID string
+	Date      time.Time
+}
+
+const entryLinkColumns = `entry_links.source_entry_id, entry_links.source_journal_id, journal_entries.entry_date,
+	entry_links.target_journal_id, entry_links.target_date, entry_links.target_entry_id, entry_links.created_at`
+
+func scanEntryLinks(rows *sql.Rows) ([]EntryLink, error) {
+	defer rows.Close()
+
+	var links []EntryLink
+	for rows.Next() {
+		var link EntryLink
+		if err := rows.Scan(
+			&link.SourceEntryID, &link.SourceJournalID, &link.SourceDate, &link.TargetJournalID,
+			&link.TargetDate, &link.TargetEntryID, &link.CreatedAt); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// Link operations
+
+// ReplaceEntryLinks replaces the outgoing links of an entry, resolving each
+// target to its entry where one exists
+func (s *Store) ReplaceEntryLinks(ctx context.Context, sourceEntryID, sourceJournalID string, targets []LinkTarget) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM entry_links
+		WHERE source_entry_id = $1`,
+		sourceEntryID); err != nil {
+		return err
+	}
+
+	for _, target := range targets {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO entry_links (source_entry_id, source_journal_id, target_journal_id, target_date, target_entry_id)
+			VALUES ($1, $2, $3, $4, (
+				SELECT id FROM journal_entries
+				WHERE journal_id = $3 AND entry_date = $4
+			))
+			ON CONFLICT DO NOTHING`,
+			sourceEntryID, sourceJournalID, target.JournalID, target.Date); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ResolveEntryLinks points existing links to a day at the entry just created for it
+func (s *Store) ResolveEntryLinks(ctx context.Context, journalID string, date time.Time, entryID string) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE entry_links
+		SET target_entry_id = $1
+		WHERE target_journal_id = $2 AND target_date = $3`,
+		entryID, journalID, date)
+	return err
+}
+
+// ListBacklinks lists links to a day from entries in journals the user is a
+// member of
+func (s *Store) ListBacklinks(ctx context.Context, journalID string, date time.Time, userSub string) ([]EntryLink, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+entryLinkColumns+`
+		FROM entry_links
+		JOIN journal_entries ON journal_entries.id = entry_links.source_entry_id
+		JOIN journal_members ON journal_members.journal_id = entry_links.source_journal_id
+			AND journal_members.user_sub = $3
+		WHERE entry_links.target_journal_id = $1 AND entry_links.target_date = $2
+		ORDER BY journal_entries.entry_date DESC`,
+		journalID, date, userSub)
+	if err != nil {
+		return nil, err
+	}
+	return scanEntryLinks(rows)
+}
+
+// ListJournalLinks lists the links made from a journal's entries, leaving out
+// links into journals the user is not a member of
+func (s *Store) ListJournalLinks(ctx context.Context, journalID, userSub string) ([]EntryLink, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+entryLinkColumns+`
+		FROM entry_links
+		JOIN journal_entries ON journal_entries.id = entry_links.source_entry_id
+		WHERE entry_links.source_journal_id = $1
+			AND (entry_links.target_journal_id = $1 OR EXISTS (
+				SELECT 1 FROM journal_members
+				WHERE journal_members.journal_id = entry_links.target_journal_id
+					AND journal_members.user_sub = $2
+			))
+		ORDER BY journal_entries.entry_date, entry_links.target_date`,
+		journalID, userSub)
+	if err != nil {
+		return nil, err
+	}
+	return scanEntryLinks(rows)
+}
@@ -0,0 +1,198 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type JournalMember struct {
+	JournalID string
+	UserSub   string
+	Role      string
+	InvitedBy sql.NullString
+	CreatedAt time.Time
+}
+
+type JournalInvite struct {
+	ID         string
+	JournalID  string
+	InviteeSub string
+	Role       string
+	InvitedBy  string
+	CreatedAt  time.Time
+	AcceptedAt sql.NullTime
+	RevokedAt  sql.NullTime
+}
+
+const inviteColumns = `id, journal_id, invitee_sub, role, invited_by, created_at, accepted_at, revoked_at`
+
+func scanInvite(row scanner) (JournalInvite, error) {
+	var invite JournalInvite
+	err := row.Scan(
+		&invite.ID, &invite.JournalID, &invite.InviteeSub, &invite.Role, &invite.InvitedBy,
+		&invite.CreatedAt, &invite.AcceptedAt, &invite.RevokedAt)
+	return invite, err
+}
+
+func scanInvites(rows *sql.Rows) ([]JournalInvite, error) {
+	defer rows.Close()
+
+	var invites []JournalInvite
+	for rows.Next() {
+		invite, err := scanInvite(rows)
+		if err != nil {
+			return nil, err
+		}
+		invites = append(invites, invite)
+	}
+	return invites, rows.Err()
+}
+
+// Member operations
+
+func (s *Store) ListJournalMembers(ctx context.Context, journalID string) ([]JournalMember, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT journal_id, user_sub, role, invited_by, created_at
+		FROM journal_members
+		WHERE journal_id = $1
+		ORDER BY created_at`,
+		journalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []JournalMember
+	for rows.Next() {
+		var member JournalMember
+		if err := rows.Scan(
+			&member.JournalID, &member.UserSub, &member.Role, &member.InvitedBy, &member.CreatedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+// RemoveJournalMember removes a non-owner member and reports whether one was removed
+func (s *Store) RemoveJournalMember(ctx context.Context, journalID, userSub string) (bool, error) {
+	removed := false
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			DELETE FROM journal_members
+			WHERE journal_id = $1 AND user_sub = $2 AND role <> 'owner'`,
+			journalID, userSub)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil || n == 0 {
+			return err
+		}
+		removed = true
+		return tombstoneJournalSync(ctx, tx, journalID, []string{userSub})
+	})
+	return removed, err
+}
+
+// Invite operations
+
+func (s *Store) CreateJournalInvite(ctx context.Context, invite JournalInvite) (JournalInvite, error) {
+	return scanInvite(s.db.QueryRowContext(ctx, `
+		INSERT INTO journal_invites (journal_id, invitee_sub, role, invited_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+inviteColumns,
+		invite.JournalID, invite.InviteeSub, invite.Role, invite.InvitedBy))
+}
+
+func (s *Store) GetJournalInvite(ctx context.Context, id string) (JournalInvite, error) {
+	return scanInvite(s.db.QueryRowContext(ctx, `
+		SELECT `+inviteColumns+`
+		FROM journal_invites
+		WHERE id = $1`,
+		id))
+}
+
+// ListJournalInvites lists a journal's pending invites
+func (s *Store) ListJournalInvites(ctx context.Context, journalID string) ([]JournalInvite, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+inviteColumns+`
+		FROM journal_invites
+		WHERE journal_id = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+		ORDER BY created_at`,
+		journalID)
+	if err != nil {
+		return nil, err
+	}
+	return scanInvites(rows)
+}
+
+// ListPendingInvites lists the invites waiting for a user to accept
+func (s *Store) ListPendingInvites(ctx context.Context, inviteeSub string) ([]JournalInvite, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+inviteColumns+`
+		FROM journal_invites
+		WHERE invitee_sub = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+		ORDER BY created_at`,
+		inviteeSub)
+	if err != nil {
+		return nil, err
+	}
+	return scanInvites(rows)
+}
+
+// AcceptJournalInvite marks a pending invite accepted and adds the invitee as
+// a member, replacing any role they already had. It reports false if the
+// invite was no longer pending.
+func (s *Store) AcceptJournalInvite(ctx context.Context, invite JournalInvite) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE journal_invites
+		SET accepted_at = NOW()
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL`,
+		invite.ID)
+	if err != nil {
+		return false, err
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO journal_members (journal_id, user_sub, role, invited_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (journal_id, user_sub) DO UPDATE
+		SET role = EXCLUDED.role, invited_by = EXCLUDED.invited_by
+		WHERE journal_members.role <> 'owner'`,
+		invite.JournalID, invite.InviteeSub, invite.Role, invite.InvitedBy); err != nil {
+		return false, err
+	}
+	if err := snapshotJournalSync(ctx, tx, invite.JournalID, invite.InviteeSub); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// RevokeJournalInvite revokes a pending invite and reports whether one was revoked
+func (s *Store) RevokeJournalInvite(ctx context.Context, id string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE journal_invites
+		SET revoked_at = NOW()
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL`,
+		id)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
@@ -0,0 +1,57 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// DefaultTimeZone applies to users who have not chosen one
+const DefaultTimeZone = "UTC"
+
+type UserSettings struct {
+	UserSub   string
+	TimeZone  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Settings operations
+
+// GetUserSettings returns a user's settings, or the defaults if they never saved any
+func (s *Store) GetUserSettings(ctx context.Context, userSub string) (UserSettings, error) {
+	var settings UserSettings
+	err := s.db.QueryRowContext(ctx, `
+		SELECT user_sub, time_zone, created_at, updated_at
+		FROM user_settings
+		WHERE user_sub = $1`,
+		userSub).Scan(&settings.UserSub, &settings.TimeZone, &settings.CreatedAt, &settings.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return UserSettings{UserSub: userSub, TimeZone: DefaultTimeZone}, nil
+	}
+	if err != nil {
+		return UserSettings{}, err
+	}
+	return settings, nil
+}
+
+func (s *Store) SaveUserSettings(ctx context.Context, settings UserSettings) (UserSettings, error) {
+	var saved UserSettings
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO user_settings (user_sub, time_zone)
+		VALUES ($1, $2)
+		ON CONFLICT (user_sub) DO UPDATE
+		SET time_zone = EXCLUDED.time_zone, updated_at = NOW()
+		RETURNING user_sub, time_zone, created_at, updated_at`,
+		settings.UserSub, settings.TimeZone).Scan(&saved.UserSub, &saved.TimeZone, &saved.CreatedAt, &saved.UpdatedAt)
+	if err != nil {
+		return UserSettings{}, err
+	}
+	return saved, nil
+}
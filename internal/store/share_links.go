@@ -0,0 +1,157 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type ShareLink struct {
+	ID           string
+	EntryID      string
+	JournalID    string
+	TokenHash    string
+	CommitHash   sql.NullString
+	PasswordHash sql.NullString
+	CreatedBy    string
+	ExpiresAt    sql.NullTime
+	RevokedAt    sql.NullTime
+	CreatedAt    time.Time
+}
+
+type ShareAccess struct {
+	ID          int64
+	ShareLinkID string
+	Outcome     string
+	RemoteAddr  sql.NullString
+	UserAgent   sql.NullString
+	AccessedAt  time.Time
+}
+
+const shareLinkColumns = `id, entry_id, journal_id, token_hash, commit_hash, password_hash, created_by, expires_at, revoked_at, created_at`
+
+func scanShareLink(row scanner) (ShareLink, error) {
+	var link ShareLink
+	err := row.Scan(
+		&link.ID, &link.EntryID, &link.JournalID, &link.TokenHash, &link.CommitHash,
+		&link.PasswordHash, &link.CreatedBy, &link.ExpiresAt, &link.RevokedAt, &link.CreatedAt)
+	return link, err
+}
+
+// Share link operations
+
+func (s *Store) CreateShareLink(ctx context.Context, link ShareLink) (ShareLink, error) {
+	return scanShareLink(s.db.QueryRowContext(ctx, `
+		INSERT INTO entry_share_links (entry_id, journal_id, token_hash, commit_hash, password_hash, created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+shareLinkColumns,
+		link.EntryID, link.JournalID, link.TokenHash, link.CommitHash, link.PasswordHash,
+		link.CreatedBy, link.ExpiresAt))
+}
+
+func (s *Store) GetShareLinkByTokenHash(ctx context.Context, tokenHash string) (ShareLink, error) {
+	return scanShareLink(s.db.QueryRowContext(ctx, `
+		SELECT `+shareLinkColumns+`
+		FROM entry_share_links
+		WHERE token_hash = $1`,
+		tokenHash))
+}
+
+func (s *Store) ListEntryShareLinks(ctx context.Context, entryID string) ([]ShareLink, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+shareLinkColumns+`
+		FROM entry_share_links
+		WHERE entry_id = $1
+		ORDER BY created_at DESC`,
+		entryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []ShareLink
+	for rows.Next() {
+		link, err := scanShareLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// ShareLinkPinsCommit reports whether an unrevoked share link of the entry
+// is pinned to the given commit
+func (s *Store) ShareLinkPinsCommit(ctx context.Context, entryID, commitHash string) (bool, error) {
+	var pinned bool
+	err := s.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM entry_share_links
+			WHERE entry_id = $1 AND commit_hash = $2 AND revoked_at IS NULL
+		)`,
+		entryID, commitHash).Scan(&pinned)
+	return pinned, err
+}
+
+// RevokeShareLink revokes an entry's share link and reports whether one was revoked
+func (s *Store) RevokeShareLink(ctx context.Context, entryID, id string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE entry_share_links
+		SET revoked_at = NOW()
+		WHERE id = $1 AND entry_id = $2 AND revoked_at IS NULL`,
+		id, entryID)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+func (s *Store) LogShareAccess(ctx context.Context, access ShareAccess) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO share_link_access_log (share_link_id, outcome, remote_addr, user_agent)
+		VALUES ($1, $2, $3, $4)`,
+		access.ShareLinkID, access.Outcome, access.RemoteAddr, access.UserAgent)
+	return err
+}
+
+func (s *Store) ListShareAccess(ctx context.Context, shareLinkID string) ([]ShareAccess, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, share_link_id, outcome, remote_addr, user_agent, accessed_at
+		FROM share_link_access_log
+		WHERE share_link_id = $1
+		ORDER BY accessed_at DESC
+		LIMIT 500`,
+		shareLinkID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var log []ShareAccess
+	for rows.Next() {
+		var access ShareAccess
+		if err := rows.Scan(
+			&access.ID, &access.ShareLinkID, &access.Outcome, &access.RemoteAddr,
+			&access.UserAgent, &access.AccessedAt); err != nil {
+			return nil, err
+		}
+		log = append(log, access)
+	}
+	return log, rows.Err()
+}
+
+// GetJournalByID loads a journal without a membership check, for access
+// that was authorized some other way
+func (s *Store) GetJournalByID(ctx context.Context, id string) (Journal, error) {
+	return scanJournal(s.db.QueryRowContext(ctx, `
+		SELECT `+journalColumns+`
+		FROM journals
+		WHERE id = $1`,
+		id))
+}
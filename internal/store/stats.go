@@ -0,0 +1,191 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// EntryStats summarises the entries of a journal or of all a user's journals
+type EntryStats struct {
+	Entries       int64
+	TotalWords    int64
+	AverageWords  float64
+	FirstEntry    sql.NullTime
+	LastEntry     sql.NullTime
+	Months        []MonthStats
+	Weekdays      []WeekdayStats // most active first
+	Days          []DayStats     // days with entries since the requested date
+	CurrentStreak Streak
+	LongestStreak Streak
+}
+
+type MonthStats struct {
+	Month   string // 2006-01
+	Entries int64
+	Words   int64
+}
+
+type WeekdayStats struct {
+	Weekday time.Weekday
+	Entries int64
+	Words   int64
+}
+
+type DayStats struct {
+	Date    time.Time
+	Entries int64
+	Words   int64
+}
+
+// Streak is a run of consecutive days with at least one entry
+type Streak struct {
+	Days  int64
+	Start sql.NullTime
+	End   sql.NullTime
+}
+
+// Statistics scopes: each selects journal_entries rows by the single argument $1
+const (
+	journalStatsScope = `journal_id = $1`
+	userStatsScope    = `journal_id IN (SELECT journal_id FROM journal_members WHERE user_sub = $1)`
+)
+
+// Statistics operations
+
+// GetJournalStats computes statistics over one journal's entries. Days are
+// listed from since onwards; a streak is current if it reaches today or
+// yesterday.
+func (s *Store) GetJournalStats(ctx context.Context, journalID string, since, today time.Time) (EntryStats, error) {
+	return s.entryStats(ctx, journalStatsScope, journalID, since, today)
+}
+
+// GetUserStats computes statistics over every journal the user is a member of
+func (s *Store) GetUserStats(ctx context.Context, userSub string, since, today time.Time) (EntryStats, error) {
+	return s.entryStats(ctx, userStatsScope, userSub, since, today)
+}
+
+func (s *Store) entryStats(ctx context.Context, scope, arg string, since, today time.Time) (EntryStats, error) {
+	var stats EntryStats
+	if err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(word_count), 0), COALESCE(AVG(word_count), 0),
+			MIN(entry_date), MAX(entry_date)
+		FROM journal_entries
+		WHERE `+scope,
+		arg).Scan(
+		&stats.Entries, &stats.TotalWords, &stats.AverageWords, &stats.FirstEntry, &stats.LastEntry); err != nil {
+		return EntryStats{}, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT to_char(entry_date, 'YYYY-MM'), COUNT(*), COALESCE(SUM(word_count), 0)
+		FROM journal_entries
+		WHERE `+scope+`
+		GROUP BY 1
+		ORDER BY 1`,
+		arg)
+	if err != nil {
+		return EntryStats{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var month MonthStats
+		if err := rows.Scan(&month.Month, &month.Entries, &month.Words); err != nil {
+			return EntryStats{}, err
+		}
+		stats.Months = append(stats.Months, month)
+	}
+	if err := rows.Err(); err != nil {
+		return EntryStats{}, err
+	}
+
+	rows, err = s.db.QueryContext(ctx, `
+		SELECT EXTRACT(DOW FROM entry_date)::int, COUNT(*), COALESCE(SUM(word_count), 0)
+		FROM journal_entries
+		WHERE `+scope+`
+		GROUP BY 1
+		ORDER BY 2 DESC, 1`,
+		arg)
+	if err != nil {
+		return EntryStats{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var weekday WeekdayStats
+		if err := rows.Scan(&weekday.Weekday, &weekday.Entries, &weekday.Words); err != nil {
+			return EntryStats{}, err
+		}
+		stats.Weekdays = append(stats.Weekdays, weekday)
+	}
+	if err := rows.Err(); err != nil {
+		return EntryStats{}, err
+	}
+
+	rows, err = s.db.QueryContext(ctx, `
+		SELECT entry_date, COUNT(*), COALESCE(SUM(word_count), 0)
+		FROM journal_entries
+		WHERE `+scope+` AND entry_date >= $2
+		GROUP BY entry_date
+		ORDER BY entry_date`,
+		arg, since)
+	if err != nil {
+		return EntryStats{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var day DayStats
+		if err := rows.Scan(&day.Date, &day.Entries, &day.Words); err != nil {
+			return EntryStats{}, err
+		}
+		stats.Days = append(stats.Days, day)
+	}
+	if err := rows.Err(); err != nil {
+		return EntryStats{}, err
+	}
+
+	// Consecutive days share the same date minus row number, which groups
+	// them into islands. Only the longest and the most recent are kept.
+	rows, err = s.db.QueryContext(ctx, `
+		WITH days AS (
+			SELECT DISTINCT entry_date
+			FROM journal_entries
+			WHERE `+scope+`
+		), streaks AS (
+			SELECT MIN(entry_date) AS start_date, MAX(entry_date) AS end_date, COUNT(*) AS days
+			FROM (
+				SELECT entry_date, entry_date - (ROW_NUMBER() OVER (ORDER BY entry_date))::int AS island
+				FROM days
+			) numbered
+			GROUP BY island
+		)
+		SELECT start_date, end_date, days
+		FROM streaks
+		WHERE days = (SELECT MAX(days) FROM streaks)
+			OR end_date = (SELECT MAX(end_date) FROM streaks)
+		ORDER BY end_date DESC`,
+		arg)
+	if err != nil {
+		return EntryStats{}, err
+	}
+	defer rows.Close()
+	first := true
+	for rows.Next() {
+		var streak Streak
+		if err := rows.Scan(&streak.Start, &streak.End, &streak.Days); err != nil {
+			return EntryStats{}, err
+		}
+		if first && !streak.End.Time.Before(today.AddDate(0, 0, -1)) {
+			stats.CurrentStreak = streak
+		}
+		if streak.Days > stats.LongestStreak.Days {
+			stats.LongestStreak = streak
+		}
+		first = false
+	}
+	return stats, rows.Err()
+}
@@ -11,7 +11,7 @@ import (
 	"fmt"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type Store struct {
@@ -19,23 +19,43 @@ type Store struct {
 }
 
 type Journal struct {
-	ID          string
-	UserSub     string
-	Title       string
-	Description sql.NullString
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID                string
+	UserSub           string
+	Title             string
+	Description       sql.NullString
+	E2EE              bool
+	DefaultTemplateID sql.NullString // applied to new entries created without content
+	Role              string         // the requesting member's role, when loaded for a member
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 type JournalEntry struct {
-	ID            string
-	JournalID     string
-	EntryDate     time.Time
-	S3Key         string
-	GitCommitHash sql.NullString
-	WordCount     sql.NullInt32
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	ID             string
+	JournalID      string
+	EntryDate      time.Time
+	S3Key          string
+	GitCommitHash  sql.NullString
+	WordCount      sql.NullInt32
+	ClientMetadata sql.NullString
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	// The author's time zone and UTC offset in seconds when the entry was created
+	CreatedTimeZone  sql.NullString
+	CreatedUTCOffset sql.NullInt32
+
+	// Taken from the entry's front matter and #hashtags, or the client metadata of e2ee journals
+	Mood sql.NullString
+	Tags []string
+}
+
+// CreatedAtLocal returns the creation time as the author's clock showed it
+func (e JournalEntry) CreatedAtLocal() (time.Time, bool) {
+	if !e.CreatedTimeZone.Valid || !e.CreatedUTCOffset.Valid {
+		return time.Time{}, false
+	}
+	return e.CreatedAt.In(time.FixedZone(e.CreatedTimeZone.String, int(e.CreatedUTCOffset.Int32))), true
 }
 
 type JournalVersion struct {
@@ -48,6 +68,59 @@ type JournalVersion struct {
 	CreatedAt     time.Time
 }
 
+// scanner is satisfied by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+const journalColumns = `journals.id, journals.user_sub, journals.title, journals.description, journals.e2ee, journals.default_template_id, journals.created_at, journals.updated_at`
+
+func scanJournal(row scanner) (Journal, error) {
+	var journal Journal
+	err := row.Scan(
+		&journal.ID, &journal.UserSub, &journal.Title, &journal.Description,
+		&journal.E2EE, &journal.DefaultTemplateID, &journal.CreatedAt, &journal.UpdatedAt)
+	return journal, err
+}
+
+// memberJournalColumns selects a journal joined with journal_members
+const memberJournalColumns = journalColumns + `, journal_members.role`
+
+func scanMemberJournal(row scanner) (Journal, error) {
+	var journal Journal
+	err := row.Scan(
+		&journal.ID, &journal.UserSub, &journal.Title, &journal.Description,
+		&journal.E2EE, &journal.DefaultTemplateID, &journal.CreatedAt, &journal.UpdatedAt, &journal.Role)
+	return journal, err
+}
+
+const entryColumns = `id, journal_id, entry_date, s3_key, git_commit_hash, word_count, client_metadata, created_at, updated_at,
+	created_time_zone, created_utc_offset, mood, tags`
+
+func scanEntry(row scanner) (JournalEntry, error) {
+	var entry JournalEntry
+	err := row.Scan(
+		&entry.ID, &entry.JournalID, &entry.EntryDate, &entry.S3Key,
+		&entry.GitCommitHash, &entry.WordCount, &entry.ClientMetadata,
+		&entry.CreatedAt, &entry.UpdatedAt, &entry.CreatedTimeZone, &entry.CreatedUTCOffset,
+		&entry.Mood, pq.Array(&entry.Tags))
+	return entry, err
+}
+
+func scanEntries(rows *sql.Rows) ([]JournalEntry, error) {
+	defer rows.Close()
+
+	var entries []JournalEntry
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
 func New(databaseURL string) (*Store, error) {
 	db, err := sql.Open("postgres", databaseURL)
 	if err != nil {
@@ -66,41 +139,68 @@ func (s *Store) DB() *sql.DB {
 
 // Journal operations
 
+// CreateJournal creates a journal and makes its user the owning member
 func (s *Store) CreateJournal(ctx context.Context, journal Journal) (Journal, error) {
-	if journal.ID == "" {
-		journal.ID = generateUUID()
-	}
-	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO journals (id, user_sub, title, description)
-		VALUES ($1, $2, $3, $4)`,
-		journal.ID, journal.UserSub, journal.Title, journal.Description)
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return Journal{}, err
 	}
+	defer tx.Rollback()
+
+	if journal.ID, err = insertJournal(ctx, tx, journal); err != nil {
+		return Journal{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Journal{}, err
+	}
 	return s.GetJournal(ctx, journal.ID, journal.UserSub)
 }
 
+// insertJournal inserts a journal with its owner membership and returns its ID
+func insertJournal(ctx context.Context, tx *sql.Tx, journal Journal) (string, error) {
+	var id string
+	if err := tx.QueryRowContext(ctx, `
+		INSERT INTO journals (user_sub, title, description, e2ee)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`,
+		journal.UserSub, journal.Title, journal.Description, journal.E2EE).Scan(&id); err != nil {
+		return "", err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO journal_members (journal_id, user_sub, role)
+		VALUES ($1, $2, 'owner')`,
+		id, journal.UserSub); err != nil {
+		return "", err
+	}
+	if err := recordSyncChange(ctx, tx, SyncEntityJournal, id, id, false); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// GetJournal loads a journal the user is a member of, with their role
 func (s *Store) GetJournal(ctx context.Context, id, userSub string) (Journal, error) {
-	var journal Journal
-	err := s.db.QueryRowContext(ctx, `
-		SELECT id, user_sub, title, description, created_at, updated_at
+	journal, err := scanMemberJournal(s.db.QueryRowContext(ctx, `
+		SELECT `+memberJournalColumns+`
 		FROM journals
-		WHERE id = $1 AND user_sub = $2`,
-		id, userSub).Scan(
-		&journal.ID, &journal.UserSub, &journal.Title, &journal.Description,
-		&journal.CreatedAt, &journal.UpdatedAt)
+		JOIN journal_members ON journal_members.journal_id = journals.id
+		WHERE journals.id = $1 AND journal_members.user_sub = $2`,
+		id, userSub))
 	if err != nil {
 		return Journal{}, err
 	}
 	return journal, nil
 }
 
+// ListJournals lists every journal the user is a member of
 func (s *Store) ListJournals(ctx context.Context, userSub string) ([]Journal, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT id, user_sub, title, description, created_at, updated_at
+		SELECT `+memberJournalColumns+`
 		FROM journals
-		WHERE user_sub = $1
-		ORDER BY created_at DESC`,
+		JOIN journal_members ON journal_members.journal_id = journals.id
+		WHERE journal_members.user_sub = $1
+		ORDER BY journals.created_at DESC`,
 		userSub)
 	if err != nil {
 		return nil, err
@@ -109,10 +209,8 @@ func (s *Store) ListJournals(ctx context.Context, userSub string) ([]Journal, er
 
 	var journals []Journal
 	for rows.Next() {
-		var journal Journal
-		if err := rows.Scan(
-			&journal.ID, &journal.UserSub, &journal.Title, &journal.Description,
-			&journal.CreatedAt, &journal.UpdatedAt); err != nil {
+		journal, err := scanMemberJournal(rows)
+		if err != nil {
 			return nil, err
 		}
 		journals = append(journals, journal)
@@ -121,20 +219,61 @@ func (s *Store) ListJournals(ctx context.Context, userSub string) ([]Journal, er
 }
 
 func (s *Store) UpdateJournal(ctx context.Context, journal Journal) error {
-	_, err := s.db.ExecContext(ctx, `
-		UPDATE journals
-		SET title = $1, description = $2, updated_at = NOW()
-		WHERE id = $3 AND user_sub = $4`,
-		journal.Title, journal.Description, journal.ID, journal.UserSub)
-	return err
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE journals
+			SET title = $1, description = $2, updated_at = NOW()
+			WHERE id = $3 AND user_sub = $4`,
+			journal.Title, journal.Description, journal.ID, journal.UserSub)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil || n == 0 {
+			return err
+		}
+		return recordSyncChange(ctx, tx, SyncEntityJournal, journal.ID, journal.ID, false)
+	})
 }
 
+// DeleteJournal deletes a journal and everything in it, leaving its members
+// a tombstone to sync
 func (s *Store) DeleteJournal(ctx context.Context, id, userSub string) error {
-	_, err := s.db.ExecContext(ctx, `
-		DELETE FROM journals
-		WHERE id = $1 AND user_sub = $2`,
-		id, userSub)
-	return err
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		// The memberships would go with the journal, so delete them first to
+		// learn who had it
+		rows, err := tx.QueryContext(ctx, `
+			DELETE FROM journal_members
+			WHERE journal_id = $1 AND EXISTS (SELECT 1 FROM journals WHERE id = $1 AND user_sub = $2)
+			RETURNING user_sub`,
+			id, userSub)
+		if err != nil {
+			return err
+		}
+		var members []string
+		for rows.Next() {
+			var member string
+			if err := rows.Scan(&member); err != nil {
+				rows.Close()
+				return err
+			}
+			members = append(members, member)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			DELETE FROM journals
+			WHERE id = $1 AND user_sub = $2`,
+			id, userSub); err != nil {
+			return err
+		}
+		if len(members) == 0 {
+			return nil
+		}
+		return tombstoneJournalSync(ctx, tx, id, members)
+	})
 }
 
 // Journal Entry operations
@@ -143,10 +282,17 @@ func (s *Store) CreateJournalEntry(ctx context.Context, entry JournalEntry) (Jou
 	if entry.ID == "" {
 		entry.ID = generateUUID()
 	}
-	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO journal_entries (id, journal_id, entry_date, s3_key, git_commit_hash, word_count)
-		VALUES ($1, $2, $3, $4, $5, $6)`,
-		entry.ID, entry.JournalID, entry.EntryDate, entry.S3Key, entry.GitCommitHash, entry.WordCount)
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO journal_entries (id, journal_id, entry_date, s3_key, git_commit_hash, word_count, client_metadata,
+				created_time_zone, created_utc_offset, mood, tags)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+			entry.ID, entry.JournalID, entry.EntryDate, entry.S3Key, entry.GitCommitHash, entry.WordCount, entry.ClientMetadata,
+			entry.CreatedTimeZone, entry.CreatedUTCOffset, entry.Mood, pq.Array(entry.Tags)); err != nil {
+			return err
+		}
+		return recordSyncChange(ctx, tx, SyncEntityEntry, entry.ID, entry.JournalID, false)
+	})
 	if err != nil {
 		return JournalEntry{}, err
 	}
@@ -154,14 +300,11 @@ func (s *Store) CreateJournalEntry(ctx context.Context, entry JournalEntry) (Jou
 }
 
 func (s *Store) GetJournalEntry(ctx context.Context, id string) (JournalEntry, error) {
-	var entry JournalEntry
-	err := s.db.QueryRowContext(ctx, `
-		SELECT id, journal_id, entry_date, s3_key, git_commit_hash, word_count, created_at, updated_at
+	entry, err := scanEntry(s.db.QueryRowContext(ctx, `
+		SELECT `+entryColumns+`
 		FROM journal_entries
 		WHERE id = $1`,
-		id).Scan(
-		&entry.ID, &entry.JournalID, &entry.EntryDate, &entry.S3Key,
-		&entry.GitCommitHash, &entry.WordCount, &entry.CreatedAt, &entry.UpdatedAt)
+		id))
 	if err != nil {
 		return JournalEntry{}, err
 	}
@@ -169,15 +312,12 @@ func (s *Store) GetJournalEntry(ctx context.Context, id string) (JournalEntry, e
 }
 
 func (s *Store) GetJournalEntryByDate(ctx context.Context, journalID string, entryDate time.Time) (JournalEntry, error) {
-	var entry JournalEntry
 	dateStr := entryDate.Format("2006-01-02")
-	err := s.db.QueryRowContext(ctx, `
-		SELECT id, journal_id, entry_date, s3_key, git_commit_hash, word_count, created_at, updated_at
+	entry, err := scanEntry(s.db.QueryRowContext(ctx, `
+		SELECT `+entryColumns+`
 		FROM journal_entries
 		WHERE journal_id = $1 AND entry_date = $2`,
-		journalID, dateStr).Scan(
-		&entry.ID, &entry.JournalID, &entry.EntryDate, &entry.S3Key,
-		&entry.GitCommitHash, &entry.WordCount, &entry.CreatedAt, &entry.UpdatedAt)
+		journalID, dateStr))
 	if err != nil {
 		return JournalEntry{}, err
 	}
@@ -186,7 +326,7 @@ func (s *Store) GetJournalEntryByDate(ctx context.Context, journalID string, ent
 
 func (s *Store) ListJournalEntries(ctx context.Context, journalID string) ([]JournalEntry, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT id, journal_id, entry_date, s3_key, git_commit_hash, word_count, created_at, updated_at
+		SELECT `+entryColumns+`
 		FROM journal_entries
 		WHERE journal_id = $1
 		ORDER BY entry_date DESC`,
@@ -194,38 +334,92 @@ func (s *Store) ListJournalEntries(ctx context.Context, journalID string) ([]Jou
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	return scanEntries(rows)
+}
 
-	var entries []JournalEntry
-	for rows.Next() {
-		var entry JournalEntry
-		if err := rows.Scan(
-			&entry.ID, &entry.JournalID, &entry.EntryDate, &entry.S3Key,
-			&entry.GitCommitHash, &entry.WordCount, &entry.CreatedAt, &entry.UpdatedAt); err != nil {
-			return nil, err
-		}
-		entries = append(entries, entry)
+// ListEntriesOnDay lists entries from earlier years written on the same
+// month and day as date, across every journal the user is a member of
+func (s *Store) ListEntriesOnDay(ctx context.Context, userSub string, date time.Time) ([]JournalEntry, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+entryColumns+`
+		FROM journal_entries
+		WHERE journal_id IN (SELECT journal_id FROM journal_members WHERE user_sub = $1)
+			AND EXTRACT(MONTH FROM entry_date) = $2
+			AND EXTRACT(DAY FROM entry_date) = $3
+			AND entry_date < $4
+		ORDER BY entry_date DESC`,
+		userSub, int(date.Month()), date.Day(), date.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
 	}
-	return entries, nil
+	return scanEntries(rows)
 }
 
 func (s *Store) UpdateJournalEntry(ctx context.Context, entry JournalEntry) error {
-	_, err := s.db.ExecContext(ctx, `
-		UPDATE journal_entries
-		SET s3_key = $1, git_commit_hash = $2, word_count = $3, updated_at = NOW()
-		WHERE id = $4`,
-		entry.S3Key, entry.GitCommitHash, entry.WordCount, entry.ID)
+	_, err := s.updateJournalEntry(ctx, entry, sql.NullString{})
 	return err
 }
 
+// UpdateJournalEntryIfCommit updates an entry only if its commit is still
+// baseCommit, and reports whether it did
+func (s *Store) UpdateJournalEntryIfCommit(ctx context.Context, entry JournalEntry, baseCommit string) (bool, error) {
+	return s.updateJournalEntry(ctx, entry, sql.NullString{String: baseCommit, Valid: true})
+}
+
+func (s *Store) updateJournalEntry(ctx context.Context, entry JournalEntry, baseCommit sql.NullString) (bool, error) {
+	updated := false
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE journal_entries
+			SET s3_key = $1, git_commit_hash = $2, word_count = $3, client_metadata = $4, mood = $5, tags = $6,
+				updated_at = NOW()
+			WHERE id = $7 AND ($8::TEXT IS NULL OR git_commit_hash = $8)`,
+			entry.S3Key, entry.GitCommitHash, entry.WordCount, entry.ClientMetadata, entry.Mood, pq.Array(entry.Tags), entry.ID,
+			baseCommit)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil || n == 0 {
+			return err
+		}
+		updated = true
+		return recordSyncChange(ctx, tx, SyncEntityEntry, entry.ID, entry.JournalID, false)
+	})
+	return updated, err
+}
+
 func (s *Store) DeleteJournalEntry(ctx context.Context, id string) error {
-	_, err := s.db.ExecContext(ctx, `
-		DELETE FROM journal_entries
-		WHERE id = $1`,
-		id)
+	_, err := s.deleteJournalEntry(ctx, id, sql.NullString{})
 	return err
 }
 
+// DeleteJournalEntryIfCommit deletes an entry only if its commit is still
+// baseCommit, and reports whether it did
+func (s *Store) DeleteJournalEntryIfCommit(ctx context.Context, id, baseCommit string) (bool, error) {
+	return s.deleteJournalEntry(ctx, id, sql.NullString{String: baseCommit, Valid: true})
+}
+
+func (s *Store) deleteJournalEntry(ctx context.Context, id string, baseCommit sql.NullString) (bool, error) {
+	deleted := false
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var journalID string
+		err := tx.QueryRowContext(ctx, `
+			DELETE FROM journal_entries
+			WHERE id = $1 AND ($2::TEXT IS NULL OR git_commit_hash = $2)
+			RETURNING journal_id`,
+			id, baseCommit).Scan(&journalID)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		deleted = true
+		return recordSyncChange(ctx, tx, SyncEntityEntry, id, journalID, true)
+	})
+	return deleted, err
+}
+
 // Journal Version operations
 
 func (s *Store) CreateJournalVersion(ctx context.Context, version JournalVersion) (JournalVersion, error) {
@@ -283,6 +477,34 @@ func (s *Store) GetJournalVersion(ctx context.Context, entryID, commitHash strin
 	return version, nil
 }
 
+// GetLatestJournalVersion gets an entry's most recent version
+func (s *Store) GetLatestJournalVersion(ctx context.Context, entryID string) (JournalVersion, error) {
+	var version JournalVersion
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id, entry_id, commit_hash, commit_message, author_name, author_email, created_at
+		FROM journal_versions
+		WHERE entry_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1`,
+		entryID).Scan(
+		&version.ID, &version.EntryID, &version.CommitHash, &version.CommitMessage,
+		&version.AuthorName, &version.AuthorEmail, &version.CreatedAt)
+	if err != nil {
+		return JournalVersion{}, err
+	}
+	return version, nil
+}
+
+// UpdateJournalVersionCommit points a version at the commit that amended it
+func (s *Store) UpdateJournalVersionCommit(ctx context.Context, id, commitHash string) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE journal_versions
+		SET commit_hash = $1
+		WHERE id = $2`,
+		commitHash, id)
+	return err
+}
+
 // Helper function to generate UUID
 func generateUUID() string {
 	// Use timestamp-based ID for now
@@ -0,0 +1,173 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Sync entity types
+const (
+	SyncEntityJournal = "journal"
+	SyncEntityEntry   = "entry"
+)
+
+// syncChangeLock serializes change log writes so that seqs commit in order
+// and a client never skips a seq that is still being written. It is taken
+// last in a transaction, after the change itself, so that it is held only
+// briefly and never waited on while holding it.
+const syncChangeLock = 0x4c4c4a53 // "LLJS"
+
+// SyncChange is the latest change to a journal or entry as one user sees it
+type SyncChange struct {
+	UserSub    string
+	EntityType string
+	EntityID   string
+	JournalID  string
+	Seq        int64
+	CreatedSeq int64
+	Deleted    bool
+	ChangedAt  time.Time
+}
+
+const syncChangeColumns = `user_sub, entity_type, entity_id, journal_id, seq, created_seq, deleted, changed_at`
+
+// upsertSyncChanges moves rows to a fresh seq. A deleted row that comes back
+// starts a new incarnation, so clients see it as created again.
+const upsertSyncChanges = `
+	ON CONFLICT (user_sub, entity_type, entity_id) DO UPDATE
+	SET seq = EXCLUDED.seq, deleted = EXCLUDED.deleted, changed_at = NOW(),
+		created_seq = CASE WHEN sync_changes.deleted AND NOT EXCLUDED.deleted
+			THEN EXCLUDED.seq ELSE sync_changes.created_seq END`
+
+// The change log is written in the same transaction as the change it
+// records, so the two cannot disagree.
+
+// recordSyncChange records a change to a journal or entry for the journal's
+// members
+func recordSyncChange(ctx context.Context, tx *sql.Tx, entityType, entityID, journalID string, deleted bool) error {
+	if err := lockSyncChanges(ctx, tx); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO sync_changes (user_sub, entity_type, entity_id, journal_id, seq, created_seq, deleted)
+		SELECT user_sub, $1, $2::UUID, $3::UUID, seq, seq, $4
+		FROM (
+			SELECT user_sub, nextval('sync_change_seq') AS seq
+			FROM journal_members
+			WHERE journal_id = $3
+		) changes`+upsertSyncChanges,
+		entityType, entityID, journalID, deleted)
+	return err
+}
+
+// snapshotJournalSync records a journal and all its entries as changed for
+// one user, such as a new member who has none of them yet
+func snapshotJournalSync(ctx context.Context, tx *sql.Tx, journalID, userSub string) error {
+	if err := lockSyncChanges(ctx, tx); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO sync_changes (user_sub, entity_type, entity_id, journal_id, seq, created_seq)
+		SELECT $2, entity_type, entity_id, $1::UUID, seq, seq
+		FROM (
+			SELECT 'journal' AS entity_type, $1::UUID AS entity_id, nextval('sync_change_seq') AS seq
+			UNION ALL
+			SELECT 'entry', id, nextval('sync_change_seq')
+			FROM journal_entries
+			WHERE journal_id = $1
+		) changes`+upsertSyncChanges,
+		journalID, userSub)
+	return err
+}
+
+// tombstoneJournalSync records a journal and all its entries as deleted for
+// the given users, such as the members of a deleted journal or a member who
+// was removed
+func tombstoneJournalSync(ctx context.Context, tx *sql.Tx, journalID string, userSubs []string) error {
+	if err := lockSyncChanges(ctx, tx); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO sync_changes (user_sub, entity_type, entity_id, journal_id, seq, created_seq, deleted)
+		SELECT user_sub, 'journal', $1::UUID, $1::UUID, seq, seq, TRUE
+		FROM (
+			SELECT user_sub, nextval('sync_change_seq') AS seq
+			FROM unnest($2::TEXT[]) AS user_sub
+		) changes`+upsertSyncChanges,
+		journalID, pq.Array(userSubs)); err != nil {
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		UPDATE sync_changes
+		SET deleted = TRUE, seq = nextval('sync_change_seq'), changed_at = NOW()
+		WHERE journal_id = $1 AND entity_type = 'entry' AND user_sub = ANY($2) AND NOT deleted`,
+		journalID, pq.Array(userSubs))
+	return err
+}
+
+func lockSyncChanges(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, syncChangeLock)
+	return err
+}
+
+// inTx runs fn in a transaction, committing if it succeeds
+func (s *Store) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListSyncChanges lists up to limit of the user's changes after seq, oldest first
+func (s *Store) ListSyncChanges(ctx context.Context, userSub string, afterSeq int64, limit int) ([]SyncChange, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+syncChangeColumns+`
+		FROM sync_changes
+		WHERE user_sub = $1 AND seq > $2
+		ORDER BY seq
+		LIMIT $3`,
+		userSub, afterSeq, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []SyncChange
+	for rows.Next() {
+		var change SyncChange
+		if err := rows.Scan(&change.UserSub, &change.EntityType, &change.EntityID, &change.JournalID,
+			&change.Seq, &change.CreatedSeq, &change.Deleted, &change.ChangedAt); err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
+
+// ListJournalEntriesByID gets the entries with the given IDs that still exist
+func (s *Store) ListJournalEntriesByID(ctx context.Context, ids []string) ([]JournalEntry, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+entryColumns+`
+		FROM journal_entries
+		WHERE id = ANY($1::UUID[])`,
+		pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	return scanEntries(rows)
+}
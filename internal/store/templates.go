@@ -0,0 +1,128 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// EntryTemplate is a personal template when JournalID is NULL, otherwise a
+// template shared with the journal's members
+type EntryTemplate struct {
+	ID        string
+	UserSub   string
+	JournalID sql.NullString
+	Name      string
+	Body      string
+	Prompts   []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+const templateColumns = `id, user_sub, journal_id, name, body, prompts, created_at, updated_at`
+
+func scanTemplate(row scanner) (EntryTemplate, error) {
+	var template EntryTemplate
+	err := row.Scan(
+		&template.ID, &template.UserSub, &template.JournalID, &template.Name, &template.Body,
+		pq.Array(&template.Prompts), &template.CreatedAt, &template.UpdatedAt)
+	return template, err
+}
+
+func scanTemplates(rows *sql.Rows) ([]EntryTemplate, error) {
+	defer rows.Close()
+
+	var templates []EntryTemplate
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
+
+// Template operations
+
+func (s *Store) CreateTemplate(ctx context.Context, template EntryTemplate) (EntryTemplate, error) {
+	return scanTemplate(s.db.QueryRowContext(ctx, `
+		INSERT INTO entry_templates (user_sub, journal_id, name, body, prompts)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+templateColumns,
+		template.UserSub, template.JournalID, template.Name, template.Body, pq.Array(template.Prompts)))
+}
+
+func (s *Store) GetTemplate(ctx context.Context, id string) (EntryTemplate, error) {
+	return scanTemplate(s.db.QueryRowContext(ctx, `
+		SELECT `+templateColumns+`
+		FROM entry_templates
+		WHERE id = $1`,
+		id))
+}
+
+// ListUserTemplates lists a user's personal templates
+func (s *Store) ListUserTemplates(ctx context.Context, userSub string) ([]EntryTemplate, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+templateColumns+`
+		FROM entry_templates
+		WHERE user_sub = $1 AND journal_id IS NULL
+		ORDER BY name`,
+		userSub)
+	if err != nil {
+		return nil, err
+	}
+	return scanTemplates(rows)
+}
+
+// ListJournalTemplates lists the templates shared with a journal's members
+func (s *Store) ListJournalTemplates(ctx context.Context, journalID string) ([]EntryTemplate, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+templateColumns+`
+		FROM entry_templates
+		WHERE journal_id = $1
+		ORDER BY name`,
+		journalID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTemplates(rows)
+}
+
+func (s *Store) UpdateTemplate(ctx context.Context, template EntryTemplate) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE entry_templates
+		SET name = $1, body = $2, prompts = $3, updated_at = NOW()
+		WHERE id = $4`,
+		template.Name, template.Body, pq.Array(template.Prompts), template.ID)
+	return err
+}
+
+func (s *Store) DeleteTemplate(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM entry_templates
+		WHERE id = $1`,
+		id)
+	return err
+}
+
+// SetDefaultTemplate sets or, with an invalid templateID, clears a journal's default template
+func (s *Store) SetDefaultTemplate(ctx context.Context, journalID string, templateID sql.NullString) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE journals
+			SET default_template_id = $1, updated_at = NOW()
+			WHERE id = $2`,
+			templateID, journalID); err != nil {
+			return err
+		}
+		return recordSyncChange(ctx, tx, SyncEntityJournal, journalID, journalID, false)
+	})
+}
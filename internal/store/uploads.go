@@ -0,0 +1,117 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// PendingUpload is a presigned direct-to-S3 upload awaiting confirmation
+type PendingUpload struct {
+	ID          string
+	UserSub     string
+	JournalID   string
+	EntryDate   time.Time
+	Kind        string // "entry" or "attachment"
+	S3Key       string
+	Filename    sql.NullString
+	ContentType string
+	SizeBytes   int64
+	ExpiresAt   time.Time
+	ConfirmedAt sql.NullTime
+	CreatedAt   time.Time
+}
+
+const uploadColumns = `id, user_sub, journal_id, entry_date, kind, s3_key, filename, content_type, size_bytes, expires_at, confirmed_at, created_at`
+
+func scanUpload(row scanner) (PendingUpload, error) {
+	var u PendingUpload
+	err := row.Scan(
+		&u.ID, &u.UserSub, &u.JournalID, &u.EntryDate, &u.Kind, &u.S3Key, &u.Filename,
+		&u.ContentType, &u.SizeBytes, &u.ExpiresAt, &u.ConfirmedAt, &u.CreatedAt)
+	return u, err
+}
+
+// Pending upload operations
+
+func (s *Store) CreatePendingUpload(ctx context.Context, u PendingUpload) (PendingUpload, error) {
+	return scanUpload(s.db.QueryRowContext(ctx, `
+		INSERT INTO pending_uploads (user_sub, journal_id, entry_date, kind, s3_key, filename, content_type, size_bytes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING `+uploadColumns,
+		u.UserSub, u.JournalID, u.EntryDate.Format("2006-01-02"), u.Kind, u.S3Key, u.Filename,
+		u.ContentType, u.SizeBytes, u.ExpiresAt))
+}
+
+func (s *Store) GetPendingUpload(ctx context.Context, id, userSub string) (PendingUpload, error) {
+	return scanUpload(s.db.QueryRowContext(ctx, `
+		SELECT `+uploadColumns+`
+		FROM pending_uploads
+		WHERE id = $1 AND user_sub = $2`,
+		id, userSub))
+}
+
+// ConfirmPendingUpload marks an upload confirmed; it reports false if it
+// already was or has expired, so only one caller can claim it
+func (s *Store) ConfirmPendingUpload(ctx context.Context, id string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE pending_uploads
+		SET confirmed_at = NOW()
+		WHERE id = $1 AND confirmed_at IS NULL AND expires_at > NOW()`,
+		id)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n == 1, err
+}
+
+// ReleasePendingUpload undoes ConfirmPendingUpload for a confirmation that
+// failed before committing anything
+func (s *Store) ReleasePendingUpload(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE pending_uploads
+		SET confirmed_at = NULL
+		WHERE id = $1`,
+		id)
+	return err
+}
+
+// ListExpiredUploads lists uploads that can no longer be confirmed, whether
+// they were or not. A confirmation claimed within the last hour may still
+// be running and is left alone.
+func (s *Store) ListExpiredUploads(ctx context.Context, before time.Time) ([]PendingUpload, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+uploadColumns+`
+		FROM pending_uploads
+		WHERE expires_at < $1
+		  AND (confirmed_at IS NULL OR confirmed_at < $1 - INTERVAL '1 hour')`,
+		before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var uploads []PendingUpload
+	for rows.Next() {
+		u, err := scanUpload(rows)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, u)
+	}
+	return uploads, rows.Err()
+}
+
+func (s *Store) DeletePendingUpload(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM pending_uploads
+		WHERE id = $1`,
+		id)
+	return err
+}
@@ -0,0 +1,236 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Webhook delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// WebhookSubscription receives events from the journals its user is a member
+// of, or from every journal when UserSub is NULL. Empty EventTypes means all.
+type WebhookSubscription struct {
+	ID         string
+	UserSub    sql.NullString
+	URL        string
+	Secret     string
+	EventTypes []string
+	Active     bool
+	CreatedBy  string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	EventID        string
+	EventType      string
+	Payload        string
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode sql.NullInt32
+	LastError      sql.NullString
+	DeliveredAt    sql.NullTime
+	CreatedAt      time.Time
+}
+
+const webhookSubscriptionColumns = `id, user_sub, url, secret, event_types, active, created_by, created_at, updated_at`
+
+const webhookDeliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+	last_status_code, last_error, delivered_at, created_at`
+
+func scanWebhookSubscription(row scanner) (WebhookSubscription, error) {
+	var sub WebhookSubscription
+	err := row.Scan(
+		&sub.ID, &sub.UserSub, &sub.URL, &sub.Secret, pq.Array(&sub.EventTypes),
+		&sub.Active, &sub.CreatedBy, &sub.CreatedAt, &sub.UpdatedAt)
+	return sub, err
+}
+
+func scanWebhookSubscriptions(rows *sql.Rows) ([]WebhookSubscription, error) {
+	defer rows.Close()
+
+	var subs []WebhookSubscription
+	for rows.Next() {
+		sub, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+func scanWebhookDelivery(row scanner) (WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	err := row.Scan(
+		&delivery.ID, &delivery.SubscriptionID, &delivery.EventID, &delivery.EventType, &delivery.Payload,
+		&delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt, &delivery.LastStatusCode,
+		&delivery.LastError, &delivery.DeliveredAt, &delivery.CreatedAt)
+	return delivery, err
+}
+
+func scanWebhookDeliveries(rows *sql.Rows) ([]WebhookDelivery, error) {
+	defer rows.Close()
+
+	var deliveries []WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// Webhook subscription operations
+
+func (s *Store) CreateWebhookSubscription(ctx context.Context, sub WebhookSubscription) (WebhookSubscription, error) {
+	return scanWebhookSubscription(s.db.QueryRowContext(ctx, `
+		INSERT INTO webhook_subscriptions (user_sub, url, secret, event_types, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+webhookSubscriptionColumns,
+		sub.UserSub, sub.URL, sub.Secret, pq.Array(sub.EventTypes), sub.CreatedBy))
+}
+
+func (s *Store) GetWebhookSubscription(ctx context.Context, id string) (WebhookSubscription, error) {
+	return scanWebhookSubscription(s.db.QueryRowContext(ctx, `
+		SELECT `+webhookSubscriptionColumns+`
+		FROM webhook_subscriptions
+		WHERE id = $1`,
+		id))
+}
+
+// ListWebhookSubscriptions lists a user's subscriptions, and the system-wide
+// ones too when includeSystem is set
+func (s *Store) ListWebhookSubscriptions(ctx context.Context, userSub string, includeSystem bool) ([]WebhookSubscription, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+webhookSubscriptionColumns+`
+		FROM webhook_subscriptions
+		WHERE user_sub = $1 OR ($2 AND user_sub IS NULL)
+		ORDER BY created_at`,
+		userSub, includeSystem)
+	if err != nil {
+		return nil, err
+	}
+	return scanWebhookSubscriptions(rows)
+}
+
+// ListSubscriptionsForEvent lists the active subscriptions an event from a
+// journal should reach: system-wide ones, those of the journal's members and
+// those of any extra audience
+func (s *Store) ListSubscriptionsForEvent(ctx context.Context, journalID, eventType string, audience []string) ([]WebhookSubscription, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+webhookSubscriptionColumns+`
+		FROM webhook_subscriptions
+		WHERE active
+			AND (user_sub IS NULL
+				OR user_sub IN (SELECT user_sub FROM journal_members WHERE journal_id = $1)
+				OR user_sub = ANY($3))
+			AND (cardinality(event_types) = 0 OR $2 = ANY(event_types))`,
+		journalID, eventType, pq.Array(audience))
+	if err != nil {
+		return nil, err
+	}
+	return scanWebhookSubscriptions(rows)
+}
+
+func (s *Store) UpdateWebhookSubscription(ctx context.Context, sub WebhookSubscription) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE webhook_subscriptions
+		SET url = $1, event_types = $2, active = $3, updated_at = NOW()
+		WHERE id = $4`,
+		sub.URL, pq.Array(sub.EventTypes), sub.Active, sub.ID)
+	return err
+}
+
+func (s *Store) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM webhook_subscriptions
+		WHERE id = $1`,
+		id)
+	return err
+}
+
+// Webhook delivery operations
+
+func (s *Store) CreateWebhookDelivery(ctx context.Context, delivery WebhookDelivery) (WebhookDelivery, error) {
+	return scanWebhookDelivery(s.db.QueryRowContext(ctx, `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+webhookDeliveryColumns,
+		delivery.SubscriptionID, delivery.EventID, delivery.EventType, delivery.Payload))
+}
+
+func (s *Store) GetWebhookDelivery(ctx context.Context, subscriptionID, id string) (WebhookDelivery, error) {
+	return scanWebhookDelivery(s.db.QueryRowContext(ctx, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries
+		WHERE id = $1 AND subscription_id = $2`,
+		id, subscriptionID))
+}
+
+// ListWebhookDeliveries lists a subscription's most recent deliveries
+func (s *Store) ListWebhookDeliveries(ctx context.Context, subscriptionID string) ([]WebhookDelivery, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries
+		WHERE subscription_id = $1
+		ORDER BY created_at DESC
+		LIMIT 100`,
+		subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	return scanWebhookDeliveries(rows)
+}
+
+// ClaimDueWebhookDeliveries picks up to limit pending deliveries that are due
+// and pushes their next attempt to leaseUntil, so that other instances skip
+// them while this one sends
+func (s *Store) ClaimDueWebhookDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]WebhookDelivery, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+webhookDeliveryColumns,
+		limit, leaseUntil)
+	if err != nil {
+		return nil, err
+	}
+	return scanWebhookDeliveries(rows)
+}
+
+// RecordWebhookAttempt stores the outcome of one delivery attempt
+func (s *Store) RecordWebhookAttempt(ctx context.Context, delivery WebhookDelivery) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = $2, next_attempt_at = $3, last_status_code = $4, last_error = $5,
+			delivered_at = $6
+		WHERE id = $7`,
+		delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastStatusCode,
+		delivery.LastError, delivery.DeliveredAt, delivery.ID)
+	return err
+}
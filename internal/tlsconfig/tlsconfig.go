@@ -0,0 +1,188 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+// Package tlsconfig builds the server TLS configuration, optionally requiring
+// client certificates, and reloads certificates when their files change.
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Reloader serves the most recently loaded certificate and client CA bundle
+type Reloader struct {
+	certFile       string
+	keyFile        string
+	clientCAFile   string
+	allowedClients []string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  map[string]time.Time
+}
+
+// New loads the server certificate and, when clientCAFile is set, the CA
+// bundle client certificates must chain to. If allowedClients is not empty
+// the client certificate's common name or a DNS name must be in it.
+func New(certFile, keyFile, clientCAFile string, allowedClients []string) (*Reloader, error) {
+	r := &Reloader{
+		certFile:       certFile,
+		keyFile:        keyFile,
+		clientCAFile:   clientCAFile,
+		allowedClients: allowedClients,
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// MutualTLS reports whether client certificates are required
+func (r *Reloader) MutualTLS() bool {
+	return r.clientCAFile != ""
+}
+
+// TLSConfig returns a server configuration that picks up reloaded files on
+// every handshake
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.current(), nil
+		},
+	}
+}
+
+// Watch polls the files every interval and reloads them when any changes.
+// A failed reload keeps the previous certificates.
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !r.changed() {
+				continue
+			}
+			if err := r.load(); err != nil {
+				log.Printf("Warning: failed to reload TLS certificates: %v", err)
+				continue
+			}
+			log.Printf("Reloaded TLS certificates")
+		}
+	}
+}
+
+func (r *Reloader) current() *tls.Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*r.cert},
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+	if r.clientCAs != nil {
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		cfg.ClientCAs = r.clientCAs
+		cfg.VerifyConnection = r.verifyClient
+	}
+	return cfg
+}
+
+// verifyClient runs after chain verification and restricts which of the
+// CA's certificates are accepted
+func (r *Reloader) verifyClient(cs tls.ConnectionState) error {
+	if len(r.allowedClients) == 0 {
+		return nil
+	}
+	if len(cs.PeerCertificates) == 0 {
+		return fmt.Errorf("client certificate required")
+	}
+	leaf := cs.PeerCertificates[0]
+	if slices.Contains(r.allowedClients, leaf.Subject.CommonName) {
+		return nil
+	}
+	for _, name := range leaf.DNSNames {
+		if slices.Contains(r.allowedClients, name) {
+			return nil
+		}
+	}
+	return fmt.Errorf("client certificate %q is not allowed", leaf.Subject.CommonName)
+}
+
+func (r *Reloader) load() error {
+	modTimes, err := r.stat()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA bundle: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("client CA bundle contains no certificates")
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *Reloader) changed() bool {
+	modTimes, err := r.stat()
+	if err != nil {
+		return false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for path, modTime := range modTimes {
+		if !modTime.Equal(r.modTimes[path]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reloader) stat() (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time, 3)
+	for _, path := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		modTimes[path] = info.ModTime()
+	}
+	return modTimes, nil
+}
@@ -0,0 +1,125 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// errForbiddenTarget is the delivery error for addresses webhooks may not
+// reach. It does not say why, so the delivery log cannot be used to probe the
+// internal network.
+var errForbiddenTarget = errors.New("webhook target address is not allowed")
+
+// blockedNetworks are ranges outside the public internet beyond what the
+// netip predicates cover
+var blockedNetworks = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // reserved
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, can embed any IPv4 address
+}
+
+// parseNetworks parses CIDRs, or single addresses, that webhooks may reach
+// even though they are private
+func parseNetworks(networks []string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, network := range networks {
+		network = strings.TrimSpace(network)
+		if network == "" {
+			continue
+		}
+		if !strings.Contains(network, "/") {
+			addr, err := netip.ParseAddr(network)
+			if err != nil {
+				return nil, fmt.Errorf("invalid webhook allowed network %q: %w", network, err)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook allowed network %q: %w", network, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// allowedAddr reports whether deliveries may connect to addr: public
+// unicast addresses, and anything in the configured allowlist
+func (d *Dispatcher) allowedAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range d.allowed {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() || addr.IsMulticast() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() {
+		return false
+	}
+	for _, prefix := range blockedNetworks {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckURL checks that a subscription URL is http or https and that every
+// address its host resolves to may be reached. Delivery checks the address
+// again when it connects, since DNS answers can change.
+func (d *Dispatcher) CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return fmt.Errorf("invalid webhook: url must be an absolute http or https URL")
+	}
+
+	host := u.Hostname()
+	var addrs []netip.Addr
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = []netip.Addr{addr}
+	} else {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		if addrs, err = net.DefaultResolver.LookupNetIP(ctx, "ip", host); err != nil || len(addrs) == 0 {
+			return fmt.Errorf("invalid webhook: cannot resolve host %q", host)
+		}
+	}
+	for _, addr := range addrs {
+		if !d.allowedAddr(addr) {
+			return fmt.Errorf("invalid webhook: host %q is not a public address", host)
+		}
+	}
+	return nil
+}
+
+// dialControl refuses connections to addresses deliveries may not reach. It
+// runs after name resolution, for every address tried, so a host that
+// resolves differently at delivery time than when it was checked is still
+// caught.
+func (d *Dispatcher) dialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errForbiddenTarget
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !d.allowedAddr(addr) {
+		return errForbiddenTarget
+	}
+	return nil
+}
@@ -0,0 +1,295 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+// Package webhooks is an events.Publisher that queues events for subscribed
+// URLs and delivers them as signed JSON, retrying failures with exponential
+// backoff. Every delivery is a row in webhook_deliveries, which doubles as
+// the delivery log.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"time"
+
+	"github.com/telluriancorp/ll-journal/internal/events"
+	"github.com/telluriancorp/ll-journal/internal/store"
+)
+
+// Delivery request headers
+const (
+	HeaderEventID   = "X-LL-Webhook-Id"
+	HeaderEventType = "X-LL-Webhook-Event"
+	HeaderTimestamp = "X-LL-Webhook-Timestamp"
+	HeaderSignature = "X-LL-Webhook-Signature"
+)
+
+const (
+	// DefaultMaxAttempts is how often a delivery is tried before it is marked failed
+	DefaultMaxAttempts = 8
+
+	// firstRetryDelay doubles after every failed attempt, up to maxRetryDelay
+	firstRetryDelay = 30 * time.Second
+	maxRetryDelay   = 6 * time.Hour
+
+	// batchSize is how many due deliveries one pass claims
+	batchSize = 20
+)
+
+// Dispatcher queues events and delivers them in the background
+type Dispatcher struct {
+	store       *store.Store
+	client      *http.Client
+	timeout     time.Duration
+	maxAttempts int
+	allowed     []netip.Prefix
+	wake        chan struct{}
+}
+
+// NewDispatcher creates a dispatcher. Deliveries only go to public addresses
+// unless they fall in allowedNetworks, a list of CIDRs or addresses.
+func NewDispatcher(store *store.Store, timeout time.Duration, maxAttempts int, allowedNetworks []string) (*Dispatcher, error) {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	allowed, err := parseNetworks(allowedNetworks)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Dispatcher{
+		store:       store,
+		timeout:     timeout,
+		maxAttempts: maxAttempts,
+		allowed:     allowed,
+		wake:        make(chan struct{}, 1),
+	}
+
+	dialer := &net.Dialer{Timeout: timeout, Control: d.dialControl}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be the address checked, not the target
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	d.client = &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		// Receivers answer; they do not get to send deliveries elsewhere
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return d, nil
+}
+
+// Publish records a delivery of the event for every subscription it reaches
+// and wakes the delivery loop. Deliveries are queued in the database before
+// it returns, so events are not lost if the process stops.
+func (d *Dispatcher) Publish(ctx context.Context, event events.Event) error {
+	subs, err := d.store.ListSubscriptionsForEvent(ctx, event.JournalID, event.Type, event.Audience)
+	if err != nil {
+		return fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	for _, sub := range subs {
+		if _, err := d.store.CreateWebhookDelivery(ctx, store.WebhookDelivery{
+			SubscriptionID: sub.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        string(payload),
+		}); err != nil {
+			return fmt.Errorf("failed to queue delivery: %w", err)
+		}
+	}
+	d.notify()
+	return nil
+}
+
+// Replay queues a new delivery of a past delivery's event. The original
+// stays in the log as it was.
+func (d *Dispatcher) Replay(ctx context.Context, delivery store.WebhookDelivery) (store.WebhookDelivery, error) {
+	replayed, err := d.store.CreateWebhookDelivery(ctx, store.WebhookDelivery{
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+	})
+	if err != nil {
+		return store.WebhookDelivery{}, fmt.Errorf("failed to queue delivery: %w", err)
+	}
+	d.notify()
+	return replayed, nil
+}
+
+func (d *Dispatcher) notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers due deliveries until ctx is cancelled. It runs after every
+// Publish and otherwise polls, which also picks up retries and deliveries
+// queued by other instances.
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+		d.deliverDue(ctx)
+	}
+}
+
+// deliverDue sends claimed deliveries until none are due
+func (d *Dispatcher) deliverDue(ctx context.Context) {
+	for {
+		// The lease outlasts every request in the batch, so a crashed
+		// instance's deliveries become due again on their own
+		lease := time.Now().Add(d.timeout*batchSize + time.Minute)
+		deliveries, err := d.store.ClaimDueWebhookDeliveries(ctx, batchSize, lease)
+		if err != nil {
+			log.Printf("Warning: failed to claim webhook deliveries: %v", err)
+			return
+		}
+
+		subs := make(map[string]store.WebhookSubscription)
+		for _, delivery := range deliveries {
+			sub, ok := subs[delivery.SubscriptionID]
+			if !ok {
+				if sub, err = d.store.GetWebhookSubscription(ctx, delivery.SubscriptionID); err != nil {
+					log.Printf("Warning: failed to load webhook subscription %s: %v", delivery.SubscriptionID, err)
+					continue
+				}
+				subs[sub.ID] = sub
+			}
+			d.attempt(ctx, sub, delivery)
+		}
+
+		if len(deliveries) < batchSize {
+			return
+		}
+	}
+}
+
+// attempt sends one delivery and records the outcome, scheduling a retry
+// when it failed and attempts remain
+func (d *Dispatcher) attempt(ctx context.Context, sub store.WebhookSubscription, delivery store.WebhookDelivery) {
+	delivery.Attempts++
+
+	var statusCode int
+	var err error
+	if sub.Active {
+		statusCode, err = d.send(ctx, sub, delivery)
+	} else {
+		err = fmt.Errorf("subscription is disabled")
+	}
+
+	delivery.LastStatusCode = sql.NullInt32{Int32: int32(statusCode), Valid: statusCode != 0}
+	switch {
+	case err == nil:
+		delivery.Status = store.DeliverySucceeded
+		delivery.LastError = sql.NullString{}
+		delivery.DeliveredAt = sql.NullTime{Time: time.Now(), Valid: true}
+	case !sub.Active || delivery.Attempts >= d.maxAttempts:
+		delivery.Status = store.DeliveryFailed
+		delivery.LastError = sql.NullString{String: err.Error(), Valid: true}
+	default:
+		delivery.Status = store.DeliveryPending
+		delivery.LastError = sql.NullString{String: err.Error(), Valid: true}
+		delivery.NextAttemptAt = time.Now().Add(Backoff(delivery.Attempts))
+	}
+
+	if err := d.store.RecordWebhookAttempt(ctx, delivery); err != nil {
+		log.Printf("Warning: failed to record webhook delivery %s: %v", delivery.ID, err)
+	}
+}
+
+// send POSTs the payload; any 2xx response counts as delivered
+func (d *Dispatcher) send(ctx context.Context, sub store.WebhookSubscription, delivery store.WebhookDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader([]byte(delivery.Payload)))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "LL-Journal-Webhooks/1.0")
+	req.Header.Set(HeaderEventID, delivery.EventID)
+	req.Header.Set(HeaderEventType, delivery.EventType)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(sub.Secret, timestamp, []byte(delivery.Payload)))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the hex HMAC-SHA256 of "timestamp.body" under the
+// subscription secret. Receivers recompute it to verify a delivery and
+// reject stale timestamps to stop replays.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff is the delay before the retry following the given attempt:
+// 30s, 1m, 2m, 4m, ... capped at 6h
+func Backoff(attempts int) time.Duration {
+	delay := firstRetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+// NewSecret generates a signing secret for a subscription
+func NewSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
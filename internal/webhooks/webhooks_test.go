@@ -0,0 +1,62 @@
+// LifeLogger LL-Journal
+// https://api.lifelogger.life
+// company: Tellurian Corp (https://www.telluriancorp.com)
+// created in: December 2025
+
+package webhooks
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"type":"entry.created"}`)
+	// HMAC-SHA256 of `1766534400.{"type":"entry.created"}` under the secret
+	const want = "d767b1178e4513dc9e473f19a0f36054de2c91b91b7825726c5ef41ff14fca3b"
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      []byte
+		same      bool
+	}{
+		{name: "same input", secret: secret, timestamp: "1766534400", body: body, same: true},
+		{name: "other secret", secret: "whsec_other", timestamp: "1766534400", body: body},
+		{name: "other timestamp", secret: secret, timestamp: "1766534401", body: body},
+		{name: "other body", secret: secret, timestamp: "1766534400", body: []byte(`{"type":"entry.deleted"}`)},
+		// The separator keeps timestamp and body from running together
+		{name: "shifted boundary", secret: secret, timestamp: "176653440", body: append([]byte("0."), body...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Sign(tt.secret, tt.timestamp, tt.body)
+			if (got == want) != tt.same {
+				t.Errorf("Sign() = %s, matches %s = %v, want %v", got, want, got == want, tt.same)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: 30 * time.Second},
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 3, want: 2 * time.Minute},
+		{attempts: 4, want: 4 * time.Minute},
+		{attempts: 10, want: 256 * time.Minute},
+		{attempts: 11, want: 6 * time.Hour},
+		{attempts: 1000, want: 6 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+-- LifeLogger LL-Journal Database Schema
+-- https://api.lifelogger.life
+-- company: Tellurian Corp (https://www.telluriancorp.com)
+-- created in: December 2025
+
+-- Per-user data keys, wrapped by the master key
+CREATE TABLE user_data_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_sub VARCHAR(255) NOT NULL,
+    version INTEGER NOT NULL,
+    wrapped_key BYTEA NOT NULL,
+    master_key_id VARCHAR(255) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    retired_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE(user_sub, version)
+);
+
+CREATE INDEX idx_data_keys_user_sub ON user_data_keys(user_sub);
@@ -0,0 +1,21 @@
+-- LifeLogger LL-Journal Database Schema
+-- https://api.lifelogger.life
+-- company: Tellurian Corp (https://www.telluriancorp.com)
+-- created in: December 2025
+
+-- End-to-end encrypted journals: the server only ever sees ciphertext
+ALTER TABLE journals ADD COLUMN e2ee BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Metadata supplied by the client for entries the server cannot read
+ALTER TABLE journal_entries ADD COLUMN client_metadata JSONB;
+
+-- Client-generated journal key, wrapped by the client; opaque to the server
+CREATE TABLE journal_key_envelopes (
+    journal_id UUID PRIMARY KEY REFERENCES journals(id) ON DELETE CASCADE,
+    algorithm VARCHAR(64) NOT NULL,
+    key_id VARCHAR(255),
+    wrapped_key TEXT NOT NULL,
+    params JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
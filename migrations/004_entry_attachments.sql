@@ -0,0 +1,20 @@
+-- LifeLogger LL-Journal Database Schema
+-- https://api.lifelogger.life
+-- company: Tellurian Corp (https://www.telluriancorp.com)
+-- created in: December 2025
+
+-- Files and images attached to entries, stored in S3 next to the entry
+CREATE TABLE entry_attachments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entry_id UUID NOT NULL REFERENCES journal_entries(id) ON DELETE CASCADE,
+    journal_id UUID NOT NULL REFERENCES journals(id) ON DELETE CASCADE,
+    s3_key VARCHAR(500) NOT NULL UNIQUE,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    checksum_sha256 VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_attachments_entry_id ON entry_attachments(entry_id);
+CREATE INDEX idx_attachments_journal_id ON entry_attachments(journal_id);
@@ -0,0 +1,22 @@
+-- LifeLogger LL-Journal Database Schema
+-- https://api.lifelogger.life
+-- company: Tellurian Corp (https://www.telluriancorp.com)
+-- created in: December 2025
+
+-- Direct-to-S3 uploads awaiting confirmation
+CREATE TABLE pending_uploads (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_sub VARCHAR(255) NOT NULL,
+    journal_id UUID NOT NULL REFERENCES journals(id) ON DELETE CASCADE,
+    entry_date DATE NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('entry', 'attachment')),
+    s3_key VARCHAR(500) NOT NULL UNIQUE,
+    filename VARCHAR(255),
+    content_type VARCHAR(255) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_pending_uploads_user_sub ON pending_uploads(user_sub);
@@ -0,0 +1,37 @@
+-- LifeLogger LL-Journal Database Schema
+-- https://api.lifelogger.life
+-- company: Tellurian Corp (https://www.telluriancorp.com)
+-- created in: December 2025
+
+-- Journal membership; journals.user_sub remains the owner
+CREATE TABLE journal_members (
+    journal_id UUID NOT NULL REFERENCES journals(id) ON DELETE CASCADE,
+    user_sub VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'editor', 'commenter', 'viewer')),
+    invited_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (journal_id, user_sub)
+);
+
+CREATE INDEX idx_journal_members_user_sub ON journal_members(user_sub);
+
+-- Every existing journal gets its owner as a member
+INSERT INTO journal_members (journal_id, user_sub, role)
+SELECT id, user_sub, 'owner' FROM journals;
+
+-- Invitations waiting for the invitee to accept
+CREATE TABLE journal_invites (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    journal_id UUID NOT NULL REFERENCES journals(id) ON DELETE CASCADE,
+    invitee_sub VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('editor', 'commenter', 'viewer')),
+    invited_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_journal_invites_journal_id ON journal_invites(journal_id);
+CREATE INDEX idx_journal_invites_invitee_sub ON journal_invites(invitee_sub);
+CREATE UNIQUE INDEX idx_journal_invites_pending ON journal_invites(journal_id, invitee_sub)
+    WHERE accepted_at IS NULL AND revoked_at IS NULL;
@@ -0,0 +1,32 @@
+-- LifeLogger LL-Journal Database Schema
+-- https://api.lifelogger.life
+-- company: Tellurian Corp (https://www.telluriancorp.com)
+-- created in: December 2025
+
+-- Public share links for single entries; only the token hash is stored
+CREATE TABLE entry_share_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entry_id UUID NOT NULL REFERENCES journal_entries(id) ON DELETE CASCADE,
+    journal_id UUID NOT NULL REFERENCES journals(id) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    commit_hash VARCHAR(40),
+    password_hash VARCHAR(255),
+    created_by VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_share_links_entry_id ON entry_share_links(entry_id);
+
+-- Every attempt to open a share link
+CREATE TABLE share_link_access_log (
+    id BIGSERIAL PRIMARY KEY,
+    share_link_id UUID NOT NULL REFERENCES entry_share_links(id) ON DELETE CASCADE,
+    outcome VARCHAR(20) NOT NULL,
+    remote_addr VARCHAR(255),
+    user_agent TEXT,
+    accessed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_share_access_link_id ON share_link_access_log(share_link_id);
@@ -0,0 +1,21 @@
+-- LifeLogger LL-Journal Database Schema
+-- https://api.lifelogger.life
+-- company: Tellurian Corp (https://www.telluriancorp.com)
+-- created in: December 2025
+
+-- Entry templates: personal when journal_id is NULL, otherwise shared with the journal's members
+CREATE TABLE entry_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_sub VARCHAR(255) NOT NULL,
+    journal_id UUID REFERENCES journals(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    prompts TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_entry_templates_user_sub ON entry_templates(user_sub) WHERE journal_id IS NULL;
+CREATE INDEX idx_entry_templates_journal_id ON entry_templates(journal_id);
+
+ALTER TABLE journals ADD COLUMN default_template_id UUID REFERENCES entry_templates(id) ON DELETE SET NULL;
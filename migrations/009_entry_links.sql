@@ -0,0 +1,20 @@
+-- LifeLogger LL-Journal Database Schema
+-- https://api.lifelogger.life
+-- company: Tellurian Corp (https://www.telluriancorp.com)
+-- created in: December 2025
+
+-- Wiki-style [[date]] links between entries. Links are kept by target date so
+-- they survive the target entry being deleted and recreated; target_entry_id
+-- is the resolved entry while one exists.
+CREATE TABLE entry_links (
+    source_entry_id UUID NOT NULL REFERENCES journal_entries(id) ON DELETE CASCADE,
+    source_journal_id UUID NOT NULL REFERENCES journals(id) ON DELETE CASCADE,
+    target_journal_id UUID NOT NULL REFERENCES journals(id) ON DELETE CASCADE,
+    target_date DATE NOT NULL,
+    target_entry_id UUID REFERENCES journal_entries(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (source_entry_id, target_journal_id, target_date)
+);
+
+CREATE INDEX idx_entry_links_target ON entry_links(target_journal_id, target_date);
+CREATE INDEX idx_entry_links_source_journal ON entry_links(source_journal_id);
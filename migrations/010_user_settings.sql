@@ -0,0 +1,17 @@
+-- LifeLogger LL-Journal Database Schema
+-- https://api.lifelogger.life
+-- company: Tellurian Corp (https://www.telluriancorp.com)
+-- created in: December 2025
+
+-- Per-user preferences; users without a row use the defaults
+CREATE TABLE user_settings (
+    user_sub VARCHAR(255) PRIMARY KEY,
+    time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Where the author was when the entry was created: their time zone and its
+-- offset from UTC in seconds at that moment
+ALTER TABLE journal_entries ADD COLUMN created_time_zone VARCHAR(64);
+ALTER TABLE journal_entries ADD COLUMN created_utc_offset INTEGER;
@@ -0,0 +1,9 @@
+-- LifeLogger LL-Journal Database Schema
+-- https://api.lifelogger.life
+-- company: Tellurian Corp (https://www.telluriancorp.com)
+-- created in: December 2025
+
+-- Mood and tags taken from each entry's front matter and hashtags
+ALTER TABLE journal_entries ADD COLUMN mood VARCHAR(64);
+ALTER TABLE journal_entries ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
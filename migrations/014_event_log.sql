@@ -0,0 +1,22 @@
+-- LifeLogger LL-Journal Database Schema
+-- https://api.lifelogger.life
+-- company: Tellurian Corp (https://www.telluriancorp.com)
+-- created in: December 2025
+
+-- Recent domain events, read by change streams. seq orders events and is the
+-- SSE event ID clients resume from. user_subs is the audience: the journal's
+-- members when the event happened, so former members still see a deletion.
+-- No foreign key on journal_id, so events outlive their journal.
+CREATE TABLE event_log (
+    seq BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    journal_id UUID NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    payload TEXT NOT NULL,
+    user_subs TEXT[] NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_event_log_user_subs ON event_log USING GIN (user_subs);
+CREATE INDEX idx_event_log_created_at ON event_log(created_at);
@@ -0,0 +1,41 @@
+-- LifeLogger LL-Journal Database Schema
+-- https://api.lifelogger.life
+-- company: Tellurian Corp (https://www.telluriancorp.com)
+-- created in: December 2025
+
+-- Delta sync change log: one row per user and journal or entry they can see,
+-- moved to a new seq every time it changes. Clients pass the highest seq they
+-- have seen and get every row past it. Deleted rows stay as tombstones.
+CREATE SEQUENCE sync_change_seq;
+
+CREATE TABLE sync_changes (
+    user_sub VARCHAR(255) NOT NULL,
+    entity_type VARCHAR(16) NOT NULL CHECK (entity_type IN ('journal', 'entry')),
+    entity_id UUID NOT NULL,
+    journal_id UUID NOT NULL,
+    seq BIGINT NOT NULL,
+    created_seq BIGINT NOT NULL, -- seq at which the user first saw this incarnation
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    changed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_sub, entity_type, entity_id)
+);
+
+CREATE INDEX idx_sync_changes_user_seq ON sync_changes(user_sub, seq);
+CREATE INDEX idx_sync_changes_journal_id ON sync_changes(journal_id);
+
+-- Existing journals and entries are the first changes every member sees
+INSERT INTO sync_changes (user_sub, entity_type, entity_id, journal_id, seq, created_seq)
+SELECT user_sub, 'journal', journal_id, journal_id, seq, seq
+FROM (
+    SELECT journal_members.user_sub, journal_members.journal_id, nextval('sync_change_seq') AS seq
+    FROM journal_members
+) members;
+
+INSERT INTO sync_changes (user_sub, entity_type, entity_id, journal_id, seq, created_seq)
+SELECT user_sub, 'entry', entry_id, journal_id, seq, seq
+FROM (
+    SELECT journal_members.user_sub, journal_entries.id AS entry_id, journal_entries.journal_id,
+        nextval('sync_change_seq') AS seq
+    FROM journal_entries
+    JOIN journal_members ON journal_members.journal_id = journal_entries.journal_id
+) entries;
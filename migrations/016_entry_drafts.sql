@@ -0,0 +1,23 @@
+-- LifeLogger LL-Journal Database Schema
+-- https://api.lifelogger.life
+-- company: Tellurian Corp (https://www.telluriancorp.com)
+-- created in: December 2025
+
+-- Unpublished work on an entry, one per member and day. The content lives in
+-- S3; publishing turns it into a single commit of the entry.
+CREATE TABLE entry_drafts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    journal_id UUID NOT NULL REFERENCES journals(id) ON DELETE CASCADE,
+    entry_date DATE NOT NULL,
+    user_sub VARCHAR(255) NOT NULL,
+    s3_key VARCHAR(500) NOT NULL,
+    base_commit_hash VARCHAR(40),
+    client_metadata JSONB,
+    word_count INTEGER,
+    saves INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(journal_id, entry_date, user_sub)
+);
+
+CREATE INDEX idx_entry_drafts_journal_user ON entry_drafts(journal_id, user_sub);
@@ -0,0 +1,16 @@
+-- LifeLogger LL-Journal Database Schema
+-- https://api.lifelogger.life
+-- company: Tellurian Corp (https://www.telluriancorp.com)
+-- created in: December 2025
+
+-- At most one active data key per user. Concurrent first encryptions could
+-- each create one; keep the newest active and retire the rest, which stay
+-- available to decrypt.
+UPDATE user_data_keys
+SET active = FALSE, retired_at = NOW()
+WHERE active AND EXISTS (
+    SELECT 1 FROM user_data_keys newer
+    WHERE newer.user_sub = user_data_keys.user_sub AND newer.active AND newer.version > user_data_keys.version
+);
+
+CREATE UNIQUE INDEX idx_data_keys_active ON user_data_keys(user_sub) WHERE active;
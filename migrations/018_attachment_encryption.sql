@@ -0,0 +1,9 @@
+-- LifeLogger LL-Journal Database Schema
+-- https://api.lifelogger.life
+-- company: Tellurian Corp (https://www.telluriancorp.com)
+-- created in: December 2025
+
+-- Whether an attachment's object is sealed with the journal owner's data key.
+-- Attachments stored before encryption at rest covered them stay plaintext
+-- until the next key rotation seals them.
+ALTER TABLE entry_attachments ADD COLUMN encrypted BOOLEAN NOT NULL DEFAULT FALSE;
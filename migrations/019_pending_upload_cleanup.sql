@@ -0,0 +1,12 @@
+-- LifeLogger LL-Journal Database Schema
+-- https://api.lifelogger.life
+-- company: Tellurian Corp (https://www.telluriancorp.com)
+-- created in: December 2025
+
+-- Expired uploads are now cleaned up whether they were confirmed or not,
+-- together with their staged object. Attachments used to be uploaded straight
+-- to their final key; drop those records so cleanup cannot delete the
+-- attachment itself.
+DELETE FROM pending_uploads
+WHERE confirmed_at IS NOT NULL
+  AND s3_key IN (SELECT s3_key FROM entry_attachments);
@@ -0,0 +1,14 @@
+-- LifeLogger LL-Journal Database Schema
+-- https://api.lifelogger.life
+-- company: Tellurian Corp (https://www.telluriancorp.com)
+-- created in: December 2025
+
+-- HMAC nonces and JWT ids already accepted, shared by every instance so a
+-- captured request cannot be replayed against another one. Rows can go once
+-- they expire, when the request could no longer pass the freshness check.
+CREATE TABLE auth_nonces (
+    nonce VARCHAR(255) PRIMARY KEY,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_auth_nonces_expires_at ON auth_nonces(expires_at);